@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"time"
 
 	"go.uber.org/dig"
 	"go.uber.org/fx/internal/fxreflect"
@@ -445,6 +446,7 @@ const (
 	_unknownHookType _lifecycleHookAnnotationType = iota
 	_onStartHookType
 	_onStopHookType
+	_onReloadHookType
 )
 
 type lifecycleHookAnnotation struct {
@@ -461,6 +463,8 @@ func (la *lifecycleHookAnnotation) String() string {
 		name = _onStartHook
 	case _onStopHookType:
 		name = _onStopHook
+	case _onReloadHookType:
+		name = _onReloadHook
 	}
 	return name
 }
@@ -648,7 +652,12 @@ func (la *lifecycleHookAnnotation) buildHookInstaller(ann *annotated) (
 			}
 			return err
 		}
-		lc.Append(la.buildHook(hookFn))
+		hook := la.buildHook(hookFn)
+		hook.Timeout = ann.HookTimeout
+		if la.Type == _onStartHookType {
+			hook.StartRetry = ann.StartRetry
+		}
+		lc.Append(hook)
 		return results
 	})
 
@@ -661,6 +670,8 @@ func (la *lifecycleHookAnnotation) buildHookInstaller(ann *annotated) (
 			scope = ann.container.Scope("onStartHookScope")
 		case _onStopHookType:
 			scope = ann.container.Scope("onStopHookScope")
+		case _onReloadHookType:
+			scope = ann.container.Scope("onReloadHookScope")
 		}
 
 		// provide the private scope with the current dependencies and results of the annotated function
@@ -854,6 +865,8 @@ func (la *lifecycleHookAnnotation) buildHook(fn func(context.Context) error) (ho
 		hook.OnStart = fn
 	case _onStopHookType:
 		hook.OnStop = fn
+	case _onReloadHookType:
+		hook.OnReload = fn
 	}
 	return hook
 }
@@ -986,6 +999,120 @@ func OnStop(onStop interface{}) Annotation {
 	}
 }
 
+// OnReload is an Annotation that appends an OnReload Hook to the application
+// Lifecycle when that function is called. This provides a way to create
+// Lifecycle OnReload (see Hook type documentation) hooks without building a
+// function that takes a dependency on the Lifecycle type.
+//
+//	fx.Provide(
+//		fx.Annotate(
+//			NewConfig,
+//			fx.OnReload(func(ctx context.Context, cfg *Config) error {
+//				return cfg.Reread()
+//			}),
+//		)
+//	)
+//
+// OnReload may be combined with other parameter and result annotations in
+// the same way as OnStart and OnStop. Only one OnReload annotation may be
+// applied to a given function at a time, however functions may be annotated
+// with other types of lifecycle Hooks, such as OnStart.
+func OnReload(onReload interface{}) Annotation {
+	return &lifecycleHookAnnotation{
+		Type:   _onReloadHookType,
+		Target: onReload,
+	}
+}
+
+// HookTimeout is an Annotation that sets the [Hook.Timeout] of the hooks
+// appended by the OnStart and OnStop annotations applied to the same
+// function.
+//
+//	fx.Provide(
+//		fx.Annotate(
+//			NewServer,
+//			fx.OnStart(func(ctx context.Context, server Server) error {
+//				return server.Listen(ctx)
+//			}),
+//			fx.HookTimeout(5*time.Second),
+//		),
+//	)
+//
+// HookTimeout must be used together with OnStart or OnStop, and only one
+// HookTimeout annotation may be applied to a given function.
+func HookTimeout(timeout time.Duration) Annotation {
+	return hookTimeoutAnnotation(timeout)
+}
+
+type hookTimeoutAnnotation time.Duration
+
+var _ Annotation = hookTimeoutAnnotation(0)
+
+func (ht hookTimeoutAnnotation) String() string {
+	return fmt.Sprintf("fx.HookTimeout(%v)", time.Duration(ht))
+}
+
+func (ht hookTimeoutAnnotation) apply(ann *annotated) error {
+	if ht <= 0 {
+		return fmt.Errorf("hook timeout must be positive, got %v", time.Duration(ht))
+	}
+	if ann.HookTimeout != 0 {
+		return errors.New("cannot apply more than one fx.HookTimeout annotation")
+	}
+	ann.HookTimeout = time.Duration(ht)
+	return nil
+}
+
+// build is a no-op: the timeout is applied to hooks as they're built.
+func (ht hookTimeoutAnnotation) build(ann *annotated) (interface{}, error) {
+	return ann.Target, nil
+}
+
+// RetryStart is an Annotation that sets the [Hook.StartRetry] policy of the
+// hook appended by the OnStart annotation applied to the same function.
+//
+//	fx.Provide(
+//		fx.Annotate(
+//			NewConsumer,
+//			fx.OnStart(func(ctx context.Context, c *Consumer) error {
+//				return c.Connect(ctx)
+//			}),
+//			fx.RetryStart(fx.RetryPolicy{MaxAttempts: 3}),
+//		),
+//	)
+//
+// RetryStart must be used together with OnStart, and only one RetryStart
+// annotation may be applied to a given function.
+func RetryStart(policy RetryPolicy) Annotation {
+	return &retryStartAnnotation{policy: policy}
+}
+
+type retryStartAnnotation struct {
+	policy RetryPolicy
+}
+
+var _ Annotation = (*retryStartAnnotation)(nil)
+
+func (ra *retryStartAnnotation) String() string {
+	return fmt.Sprintf("fx.RetryStart(%+v)", ra.policy)
+}
+
+func (ra *retryStartAnnotation) apply(ann *annotated) error {
+	if ra.policy.Jitter < 0 || ra.policy.Jitter > 1 {
+		return fmt.Errorf("retry jitter must be between 0 and 1, got %v", ra.policy.Jitter)
+	}
+	if ann.StartRetry != nil {
+		return errors.New("cannot apply more than one fx.RetryStart annotation")
+	}
+	ann.StartRetry = &ra.policy
+	return nil
+}
+
+// build is a no-op: the policy is applied to the OnStart hook as it's built.
+func (ra *retryStartAnnotation) build(ann *annotated) (interface{}, error) {
+	return ann.Target, nil
+}
+
 type asAnnotation struct {
 	targets []interface{}
 	types   []reflect.Type
@@ -1365,6 +1492,8 @@ type annotated struct {
 	From        []reflect.Type
 	FuncPtr     uintptr
 	Hooks       []*lifecycleHookAnnotation
+	HookTimeout time.Duration
+	StartRetry  *RetryPolicy
 	// container is used to build private scopes for lifecycle hook functions
 	// added via fx.OnStart and fx.OnStop annotations.
 	container *dig.Container
@@ -1418,6 +1547,13 @@ func (ann *annotated) Build() (interface{}, error) {
 		}
 	}
 
+	if ann.HookTimeout > 0 && len(lcHookAnns) == 0 {
+		return nil, errors.New("fx.HookTimeout may only be used with fx.OnStart or fx.OnStop")
+	}
+	if ann.StartRetry != nil && !hasHookType(lcHookAnns, _onStartHookType) {
+		return nil, errors.New("fx.RetryStart may only be used with fx.OnStart")
+	}
+
 	// need to call cleanUpAsResults before applying lifecycle annotations
 	// to exclude the original results from the hook's scope if any
 	// fx.As annotations were applied
@@ -1431,6 +1567,15 @@ func (ann *annotated) Build() (interface{}, error) {
 	return ann.Target, nil
 }
 
+func hasHookType(anns []*lifecycleHookAnnotation, t _lifecycleHookAnnotationType) bool {
+	for _, la := range anns {
+		if la.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
 // applyOptionalTag checks if function being annotated is variadic
 // and applies optional tag to the variadic argument before
 // applying any other annotations
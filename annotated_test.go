@@ -28,6 +28,7 @@ import (
 	"io"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -1948,6 +1949,94 @@ func TestHookAnnotations(t *testing.T) {
 		require.NoError(t, app.Err())
 		defer app.RequireStart().RequireStop()
 	})
+
+	t.Run("with hook timeout", func(t *testing.T) {
+		t.Parallel()
+
+		type A interface{}
+
+		release := make(chan struct{})
+		defer close(release)
+
+		app := fx.New(
+			fx.NopLogger,
+			fx.Provide(
+				fx.Annotate(
+					func() A { return nil },
+					fx.OnStart(func(context.Context) error {
+						<-release // ignores the context
+						return nil
+					}),
+					fx.HookTimeout(time.Millisecond),
+				),
+			),
+			fx.Invoke(func(A) {}),
+		)
+		require.NoError(t, app.Err())
+
+		err := app.Start(context.Background())
+		require.Error(t, err)
+
+		var timeoutErr *fx.HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStart", timeoutErr.Method)
+		assert.Equal(t, time.Millisecond, timeoutErr.Timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("with retry policy", func(t *testing.T) {
+		t.Parallel()
+
+		type A interface{}
+
+		var attempts int
+		app := fxtest.New(t,
+			fx.Provide(
+				fx.Annotate(
+					func() A { return nil },
+					fx.OnStart(func(context.Context) error {
+						attempts++
+						if attempts < 3 {
+							return errors.New("not yet")
+						}
+						return nil
+					}),
+					fx.RetryStart(fx.RetryPolicy{
+						MaxAttempts:    3,
+						InitialBackoff: time.Millisecond,
+					}),
+				),
+			),
+			fx.Invoke(func(A) {}),
+		)
+		defer app.RequireStart().RequireStop()
+
+		assert.Equal(t, 3, attempts)
+	})
+
+	t.Run("with reload hook", func(t *testing.T) {
+		t.Parallel()
+
+		type A interface{}
+
+		var reloads int
+		app := fxtest.New(t,
+			fx.Provide(
+				fx.Annotate(
+					func() A { return nil },
+					fx.OnReload(func(context.Context, A) error {
+						reloads++
+						return nil
+					}),
+				),
+			),
+			fx.Invoke(func(A) {}),
+		)
+		defer app.RequireStart().RequireStop()
+
+		require.NoError(t, app.Reload(context.Background()))
+		assert.Equal(t, 1, reloads)
+	})
 }
 
 func TestHookAnnotationFailures(t *testing.T) {
@@ -2053,6 +2142,61 @@ func TestHookAnnotationFailures(t *testing.T) {
 				}),
 			),
 		},
+		{
+			name:        "with multiple hook timeouts",
+			errContains: "cannot apply more than one fx.HookTimeout annotation",
+			annotation: fx.Annotate(
+				func() A { return nil },
+				fx.OnStart(func(context.Context) error { return nil }),
+				fx.HookTimeout(time.Second),
+				fx.HookTimeout(time.Second),
+			),
+		},
+		{
+			name:        "with non-positive hook timeout",
+			errContains: "hook timeout must be positive",
+			annotation: fx.Annotate(
+				func() A { return nil },
+				fx.OnStart(func(context.Context) error { return nil }),
+				fx.HookTimeout(0),
+			),
+		},
+		{
+			name:        "with hook timeout but no hooks",
+			errContains: "fx.HookTimeout may only be used with fx.OnStart or fx.OnStop",
+			annotation: fx.Annotate(
+				func() A { return nil },
+				fx.HookTimeout(time.Second),
+			),
+		},
+		{
+			name:        "with multiple retry policies",
+			errContains: "cannot apply more than one fx.RetryStart annotation",
+			annotation: fx.Annotate(
+				func() A { return nil },
+				fx.OnStart(func(context.Context) error { return nil }),
+				fx.RetryStart(fx.RetryPolicy{}),
+				fx.RetryStart(fx.RetryPolicy{}),
+			),
+		},
+		{
+			name:        "with invalid retry jitter",
+			errContains: "retry jitter must be between 0 and 1",
+			annotation: fx.Annotate(
+				func() A { return nil },
+				fx.OnStart(func(context.Context) error { return nil }),
+				fx.RetryStart(fx.RetryPolicy{Jitter: 2}),
+			),
+		},
+		{
+			name:        "with retry policy but no OnStart hook",
+			errContains: "fx.RetryStart may only be used with fx.OnStart",
+			annotation: fx.Annotate(
+				func() A { return nil },
+				fx.OnStop(func(context.Context) error { return nil }),
+				fx.RetryStart(fx.RetryPolicy{}),
+			),
+		},
 		{
 			name:        "with nil hook target",
 			errContains: "cannot use nil function",
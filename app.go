@@ -25,6 +25,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strings"
@@ -36,6 +37,7 @@ import (
 	"go.uber.org/fx/internal/fxlog"
 	"go.uber.org/fx/internal/fxreflect"
 	"go.uber.org/fx/internal/lifecycle"
+	"go.uber.org/fx/internal/sdnotify"
 	"go.uber.org/multierr"
 )
 
@@ -186,6 +188,162 @@ func (o recoverFromPanicsOption) String() string {
 	return "fx.RecoverFromPanics()"
 }
 
+// ConcurrentLifecycle causes the application's OnStart and OnStop hooks to
+// run concurrently where possible.
+//
+// Fx uses the dependency graph to determine which constructor appended each
+// hook. A hook's OnStart function runs only after the OnStart functions of
+// all hooks appended by the constructor's dependencies have succeeded, and
+// its OnStop function runs only after the OnStop functions of all hooks
+// appended by constructors that depend on it have returned. Hooks appended
+// outside of a constructor, for example from an [Invoke], run after all
+// hooks appended before them and before any hooks appended after them.
+//
+// As with sequential startup, if any OnStart hook fails, no further hooks
+// are started and the hooks that did start are stopped.
+//
+// Because hooks run concurrently, the fxevent.Logger used by the
+// application must be safe for concurrent use.
+func ConcurrentLifecycle() Option {
+	return concurrentLifecycleOption{}
+}
+
+type concurrentLifecycleOption struct{}
+
+func (o concurrentLifecycleOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.ConcurrentLifecycle Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.concurrentLifecycle = true
+	}
+}
+
+func (o concurrentLifecycleOption) String() string {
+	return "fx.ConcurrentLifecycle()"
+}
+
+// SlowHookThreshold reports OnStart and OnStop hooks that take longer than
+// the given duration to run. While such a hook is running, the application
+// emits an fxevent.OnStartSlow or fxevent.OnStopSlow event every time the
+// threshold elapses, so hooks that hang can be identified before the start
+// or stop deadline is reached.
+//
+// These events are emitted from a separate goroutine, so the fxevent.Logger
+// used by the application must be safe for concurrent use.
+func SlowHookThreshold(d time.Duration) Option {
+	return slowHookThresholdOption(d)
+}
+
+type slowHookThresholdOption time.Duration
+
+func (t slowHookThresholdOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.SlowHookThreshold Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else if t <= 0 {
+		m.app.err = fmt.Errorf("fx.SlowHookThreshold must be positive, got %v", time.Duration(t))
+	} else {
+		m.app.slowHookThreshold = time.Duration(t)
+	}
+}
+
+func (t slowHookThresholdOption) String() string {
+	return fmt.Sprintf("fx.SlowHookThreshold(%v)", time.Duration(t))
+}
+
+// DumpGoroutinesOnTimeout records the stack trace of the goroutine running a
+// hook that times out, whether it exceeds its own [Hook.Timeout] or the
+// application's start or stop deadline. The stack trace is included in the
+// returned [HookTimeoutError] and logged as an fxevent.GoroutineDump event.
+func DumpGoroutinesOnTimeout() Option {
+	return dumpGoroutinesOnTimeoutOption{}
+}
+
+type dumpGoroutinesOnTimeoutOption struct{}
+
+func (o dumpGoroutinesOnTimeoutOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.DumpGoroutinesOnTimeout Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.dumpGoroutinesOnTimeout = true
+	}
+}
+
+func (o dumpGoroutinesOnTimeoutOption) String() string {
+	return "fx.DumpGoroutinesOnTimeout()"
+}
+
+// StopGracePeriod is how long each OnStop hook may run after the stop
+// deadline has passed when the application uses ContinueAfterDeadline.
+const StopGracePeriod = time.Second
+
+// DeadlinePolicy specifies what the application does with the remaining
+// OnDrain and OnStop hooks when the context passed to App.Stop ends.
+type DeadlinePolicy int
+
+const (
+	// AbortAtDeadline makes App.Stop return as soon as its context ends,
+	// without calling the remaining hooks. This is the default.
+	AbortAtDeadline DeadlinePolicy = iota
+
+	// ContinueAfterDeadline makes App.Stop keep calling the remaining OnStop
+	// hooks after its context ends so that they can still release their
+	// resources. Each of them runs with a fresh context that expires after
+	// StopGracePeriod, and App.Stop stops waiting for a hook once that
+	// context ends. A hook without its own Hook.Timeout that is still
+	// running when the deadline passes is given the same grace period to
+	// return.
+	//
+	// OnDrain hooks that have not run by the deadline are skipped.
+	//
+	// App.Stop returns only after all hooks have been called or given up
+	// on. Its error combines an error for each hook that was skipped, timed
+	// out, or failed; use multierr.Errors to list them.
+	ContinueAfterDeadline
+)
+
+func (p DeadlinePolicy) String() string {
+	switch p {
+	case AbortAtDeadline:
+		return "fx.AbortAtDeadline"
+	case ContinueAfterDeadline:
+		return "fx.ContinueAfterDeadline"
+	default:
+		return fmt.Sprintf("fx.DeadlinePolicy(%d)", int(p))
+	}
+}
+
+// StopPolicy specifies what the application does when the context passed to
+// App.Stop ends before all OnDrain and OnStop hooks have run. See
+// DeadlinePolicy for the available policies.
+//
+//	fx.New(
+//		fx.StopPolicy(fx.ContinueAfterDeadline),
+//		...
+//	)
+func StopPolicy(p DeadlinePolicy) Option {
+	return stopPolicyOption(p)
+}
+
+type stopPolicyOption DeadlinePolicy
+
+func (o stopPolicyOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.StopPolicy Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else if p := DeadlinePolicy(o); p != AbortAtDeadline && p != ContinueAfterDeadline {
+		m.app.err = fmt.Errorf("fx.StopPolicy received unknown policy %v", p)
+	} else {
+		m.app.stopPolicy = p
+	}
+}
+
+func (o stopPolicyOption) String() string {
+	return fmt.Sprintf("fx.StopPolicy(%v)", DeadlinePolicy(o))
+}
+
 // WithLogger specifies how Fx should build an fxevent.Logger to log its events
 // to. The argument must be a constructor with one of the following return
 // types.
@@ -307,10 +465,45 @@ type App struct {
 	validate   bool
 	// Whether to recover from panics in Dig container
 	recoverFromPanics bool
+	// Whether to run lifecycle hooks concurrently in dependency order.
+	concurrentLifecycle bool
+	// If positive, hooks running longer than this are reported.
+	slowHookThreshold time.Duration
+	// Whether to record the stacks of hooks that time out.
+	dumpGoroutinesOnTimeout bool
+	// What to do with the remaining stop hooks after the stop deadline.
+	stopPolicy DeadlinePolicy
+	// Maps the error that made Run fail to an exit code.
+	exitCodeMapper func(error) int
+	// Exit code after a GoSafe goroutine panics.
+	panicExitCode int
+	// Whether to report provided values that nothing consumes.
+	reportUnused bool
+	// Where to write the trace of the application, if anywhere, and
+	// the tracer recording it.
+	traceWriter io.Writer
+	tracer      *tracer
+	// Constructors and decorators in the order they were provided.
+	constructors []*constructorNode
+	decorators   []*decoratorNode
+	// Constructors indexed by the values they produce, the constructor
+	// that is currently running, if any, and the name of the module whose
+	// invoke is currently running. These identify the owners of lifecycle
+	// hooks.
+	providers      map[string][]*constructorNode
+	runningCtor    *constructorNode
+	invokingModule string
 
 	// Used to signal shutdowns.
 	receivers signalReceivers
 
+	// Reports state changes to systemd; nil unless SystemdNotify is used
+	// under systemd.
+	notifier *sdnotify.Notifier
+
+	// Creates listeners and passes them on with Upgrade.
+	listeners *listenerRegistry
+
 	osExit func(code int) // os.Exit override; used for testing only
 }
 
@@ -328,6 +521,9 @@ type provide struct {
 
 	// Set if the type should be provided at private scope.
 	Private bool
+
+	// Set for the values Fx provides to all applications.
+	IsBuiltin bool
 }
 
 // invoke is a single invocation request to Fx.
@@ -413,10 +609,12 @@ func New(opts ...Option) *App {
 	logger := fxlog.DefaultLogger(os.Stderr)
 
 	app := &App{
-		clock:        fxclock.System,
-		startTimeout: DefaultTimeout,
-		stopTimeout:  DefaultTimeout,
-		receivers:    newSignalReceivers(),
+		clock:         fxclock.System,
+		startTimeout:  DefaultTimeout,
+		stopTimeout:   DefaultTimeout,
+		receivers:     newSignalReceivers(),
+		listeners:     new(listenerRegistry),
+		panicExitCode: DefaultPanicExitCode,
 	}
 	app.root = &module{
 		app: app,
@@ -440,6 +638,12 @@ func New(opts ...Option) *App {
 		opt.apply(app.root)
 	}
 
+	if app.traceWriter != nil {
+		app.tracer = newTracer(app.traceWriter, app.clock)
+		endNew := app.tracer.start("fx", "New", "")
+		defer func() { endNew(app.err) }()
+	}
+
 	// There are a few levels of wrapping on the lifecycle here. To quickly
 	// cover them:
 	//
@@ -450,8 +654,25 @@ func New(opts ...Option) *App {
 	// - appLogger ensures that the lifecycle always logs events to the
 	//   "current" logger associated with the fx.App.
 	app.lifecycle = &lifecycleWrapper{
-		lifecycle.New(appLogger{app}, app.clock),
+		Lifecycle: lifecycle.New(appLogger{app}, app.clock),
+		app:       app,
+	}
+	if app.concurrentLifecycle {
+		app.lifecycle.RunConcurrently()
 	}
+	if app.slowHookThreshold > 0 {
+		app.lifecycle.WarnSlowHooks(app.slowHookThreshold)
+	}
+	if app.dumpGoroutinesOnTimeout {
+		app.lifecycle.DumpGoroutinesOnTimeout()
+	}
+	if app.stopPolicy == ContinueAfterDeadline {
+		app.lifecycle.ContinueAfterDeadline(StopGracePeriod)
+	}
+	if err := app.listeners.inheritUpgrade(); err != nil {
+		app.err = multierr.Append(app.err, err)
+	}
+	app.providers = make(map[string][]*constructorNode)
 
 	containerOptions := []dig.Option{
 		dig.DeferAcyclicVerification(),
@@ -473,12 +694,16 @@ func New(opts ...Option) *App {
 	}
 
 	frames := fxreflect.CallerStack(0, 0) // include New in the stack for default Provides
-	app.root.provide(provide{
-		Target: func() Lifecycle { return app.lifecycle },
-		Stack:  frames,
-	})
-	app.root.provide(provide{Target: app.shutdowner, Stack: frames})
-	app.root.provide(provide{Target: app.dotGraph, Stack: frames})
+	for _, target := range []interface{}{
+		func() Lifecycle { return app.lifecycle },
+		app.shutdowner,
+		app.dotGraph,
+		func() Listeners { return app.listeners },
+		app.goSafe,
+		app.introspector,
+	} {
+		app.root.provide(provide{Target: target, Stack: frames, IsBuiltin: true})
+	}
 
 	// Run decorators before executing any Invokes -- including the one
 	// inside constructCustomLogger.
@@ -511,6 +736,11 @@ func New(opts ...Option) *App {
 			}
 		}
 		errorHandlerList(app.errorHooks).HandleError(err)
+		return app
+	}
+
+	if app.reportUnused {
+		app.logUnused()
 	}
 
 	return app
@@ -561,43 +791,105 @@ func (app *App) exit(code int) {
 	osExit(code)
 }
 
+// ForceExitCode is the exit code of an application that was made to exit
+// by a second signal with ForceExitOnSecondSignal. It is distinct from the
+// exit code 1 of an application that failed to start or stop, and from the
+// exit code 2 of a Go program that panicked.
+const ForceExitCode = 3
+
+// forceExit exits the application immediately in response to a signal
+// received while it was already shutting down.
+func (app *App) forceExit(sig os.Signal) {
+	app.log().LogEvent(&fxevent.ForceExiting{
+		Signal:     sig,
+		CallerName: app.lifecycle.RunningHookCaller(),
+	})
+	app.exit(ForceExitCode)
+}
+
 // Run starts the application, blocks on the signals channel, and then
 // gracefully shuts the application down. It uses DefaultTimeout to set a
 // deadline for application startup and shutdown, unless the user has
 // configured different timeouts with the StartTimeout or StopTimeout options.
 // It's designed to make typical applications simple to run.
 //
+// If the application is shut down with a Shutdowner, Run exits with the
+// ExitCode passed to Shutdown, if any. If Shutdown was given a ShutdownCause
+// but no ExitCode, or if the application fails to start or stop, Run exits
+// with the code given to WithExitCode for the error, or as mapped by
+// ExitCodeMapper, and with 1 by default.
+//
 // However, all of Run's functionality is implemented in terms of the exported
-// Start, Done, and Stop methods. Applications with more specialized needs
+// Start, Wait, and Stop methods. Applications with more specialized needs
 // can use those methods directly instead of relying on Run.
 func (app *App) Run() {
 	// Historically, we do not os.Exit(0) even though most applications
 	// cede control to Fx with they call app.Run. To avoid a breaking
 	// change, never os.Exit for success.
-	if code := app.run(app.Done()); code != 0 {
+	if code, _ := app.run(context.Background(), app.Wait()); code != 0 {
 		app.exit(code)
 	}
 }
 
-func (app *App) run(done <-chan os.Signal) (exitCode int) {
-	startCtx, cancel := app.clock.WithTimeout(context.Background(), app.StartTimeout())
+// RunContext is like Run, but it also stops the application when ctx is
+// done, and it returns instead of exiting the process. Use it to run an
+// application as part of a larger program.
+//
+// RunContext starts the application within StartTimeout, blocks until it
+// receives a shutdown signal or ctx is done, and then stops the application
+// within StopTimeout. It returns nil if the application shut down cleanly.
+// Otherwise, it returns the errors that made the application fail to start
+// or stop, along with the ShutdownCause passed to Shutdown, if any.
+//
+// The returned error also carries the code that Run would have exited the
+// process with. Retrieve it with errors.As:
+//
+//	var exit interface{ ExitCode() int }
+//	if errors.As(err, &exit) {
+//		os.Exit(exit.ExitCode())
+//	}
+func (app *App) RunContext(ctx context.Context) error {
+	code, err := app.run(ctx, app.Wait())
+	if code == 0 {
+		return err
+	}
+	if err == nil {
+		err = fmt.Errorf("shut down with exit code %d", code)
+	}
+	return &exitCodeError{err: err, code: code}
+}
+
+func (app *App) run(ctx context.Context, wait <-chan ShutdownSignal) (exitCode int, err error) {
+	startCtx, cancel := app.clock.WithTimeout(ctx, app.StartTimeout())
 	defer cancel()
 
 	if err := app.Start(startCtx); err != nil {
-		return 1
+		return app.exitCode(err), err
 	}
 
-	sig := <-done
-	app.log().LogEvent(&fxevent.Stopping{Signal: sig})
+	// The caller stopped us by cancelling ctx, so there's no signal to
+	// report, and it's not a failure.
+	var sig ShutdownSignal
+	select {
+	case sig = <-wait:
+		app.log().LogEvent(&fxevent.Stopping{Signal: sig.Signal, Cause: sig.Cause})
+	case <-ctx.Done():
+	}
+
+	exitCode = sig.ExitCode
+	if exitCode == 0 && sig.Cause != nil {
+		exitCode = app.exitCode(sig.Cause)
+	}
 
 	stopCtx, cancel := app.clock.WithTimeout(context.Background(), app.StopTimeout())
 	defer cancel()
 
-	if err := app.Stop(stopCtx); err != nil {
-		return 1
+	stopErr := app.Stop(stopCtx)
+	if stopErr != nil && exitCode == 0 {
+		exitCode = app.exitCode(stopErr)
 	}
 
-	return 0
+	return exitCode, multierr.Combine(sig.Cause, stopErr)
 }
 
 // Err returns any error encountered during New's initialization. See the
@@ -612,8 +904,9 @@ func (app *App) Err() error {
 }
 
 var (
-	_onStartHook = "OnStart"
-	_onStopHook  = "OnStop"
+	_onStartHook  = "OnStart"
+	_onStopHook   = "OnStop"
+	_onReloadHook = "OnReload"
 )
 
 // Start kicks off all long-running goroutines, like network servers or
@@ -629,13 +922,39 @@ var (
 // Lifecycle, one at a time and in order. This ensures that each constructor's
 // start hooks aren't executed until all its dependencies' start hooks
 // complete. If any of the start hooks return an error, Start short-circuits,
-// calls Stop, and returns the inciting error.
+// calls Stop, and returns the inciting error. With the ConcurrentLifecycle
+// option, independent hooks run concurrently instead.
+//
+// Once all OnStart hooks have succeeded, Start executes all OnReady hooks,
+// one at a time and in order. A failing OnReady hook is handled like a
+// failing OnStart hook.
 //
 // Note that Start short-circuits immediately if the New constructor
 // encountered any errors in application initialization.
+//
+// If the application was asked to shut down with a Shutdowner before Start,
+// for example from a constructor or an fx.Invoke, Start returns right away
+// without executing any hooks, and the shutdown signal is delivered to the
+// channels returned by Done and Wait. A shutdown requested while Start runs
+// hooks doesn't stop it, but is delivered once Start returns.
 func (app *App) Start(ctx context.Context) (err error) {
+	endStart := app.tracer.start("fx", "Start", "")
+	if app.err == nil {
+		if sig, ok := app.receivers.TakeEarly(); ok {
+			app.log().LogEvent(&fxevent.StartSkipped{Signal: sig.Signal})
+			app.traceLifecycle(endStart, nil)
+			return nil
+		}
+	}
+	defer func() { app.traceLifecycle(endStart, err, app.lifecycle.StartRecords) }()
+
 	defer func() {
 		app.log().LogEvent(&fxevent.Started{Err: err})
+		if err == nil {
+			app.listeners.started()
+			app.sdNotify(sdnotify.Ready)
+			app.notifier.StartWatchdog()
+		}
 	}()
 
 	if app.err != nil {
@@ -678,6 +997,12 @@ func (app *App) start(ctx context.Context) error {
 		if err := app.lifecycle.Start(ctx); err != nil {
 			return err
 		}
+
+		var reload func(os.Signal)
+		if app.lifecycle.HasReloadHooks() {
+			reload = app.reloadOnSignal
+		}
+		app.receivers.SetReload(reload)
 		app.receivers.Start(ctx)
 		return nil
 	})
@@ -685,13 +1010,26 @@ func (app *App) start(ctx context.Context) error {
 
 // Stop gracefully stops the application. It executes any registered OnStop
 // hooks in reverse order, so that each constructor's stop hooks are called
-// before its dependencies' stop hooks.
+// before its dependencies' stop hooks. Before any OnStop hook, Stop executes
+// the OnDrain hooks of hooks whose OnReady phase was called, also in reverse
+// order.
 //
 // If the application didn't start cleanly, only hooks whose OnStart phase was
 // called are executed. However, all those hooks are executed, even if some
 // fail.
+//
+// By default, Stop returns as soon as ctx ends, skipping the remaining hooks.
+// Use StopPolicy to keep calling them instead.
 func (app *App) Stop(ctx context.Context) (err error) {
+	if app.State() != StateStopped {
+		app.sdNotify(sdnotify.Stopping)
+	}
+
+	endStop := app.tracer.start("fx", "Stop", "")
+	defer func() { app.traceLifecycle(endStop, err, app.lifecycle.StopRecords) }()
+
 	defer func() {
+		app.notifier.StopWatchdog()
 		app.log().LogEvent(&fxevent.Stopped{Err: err})
 	}()
 
@@ -705,9 +1043,117 @@ func (app *App) Stop(ctx context.Context) (err error) {
 		callback:  cb,
 		lifecycle: app.lifecycle,
 		log:       app.log(),
+		wait:      app.stopPolicy == ContinueAfterDeadline,
+	})
+}
+
+// Restart stops and starts the application again without rebuilding its
+// dependency graph. It executes all registered OnDrain and OnStop hooks as
+// Stop does, followed by all registered OnStart and OnReady hooks as Start
+// does. The provided context bounds the entire restart.
+//
+// Hooks appended to the Lifecycle while the application was running, for
+// example from within an OnStart hook, are kept and run in append order on
+// every subsequent start and stop, just like hooks appended during
+// initialization.
+//
+// Channels previously returned by Done and Wait remain valid across
+// restarts: a signal or Shutdown received after Restart returns is still
+// delivered to them. If an OnStop hook fails, Restart returns the error
+// without starting the application again. If an OnStart hook fails, the
+// application is rolled back as with Start and left stopped.
+func (app *App) Restart(ctx context.Context) (err error) {
+	app.log().LogEvent(&fxevent.Restarting{})
+	endRestart := app.tracer.start("fx", "Restart", "")
+	defer func() {
+		app.log().LogEvent(&fxevent.Restarted{Err: err})
+		if err == nil {
+			app.sdNotify(sdnotify.Ready)
+		}
+		// restart traces the hooks of the phases that ran.
+		app.traceLifecycle(endRestart, err)
+	}()
+
+	if app.err != nil {
+		return app.err
+	}
+
+	return withTimeout(ctx, &withTimeoutParams{
+		hook:      _onStartHook,
+		callback:  app.restart,
+		lifecycle: app.lifecycle,
+		log:       app.log(),
+	})
+}
+
+// Reload runs the OnReload hooks of a started application in the order they
+// were appended, for example to make components re-read their configuration.
+// Unlike Start, Reload keeps going after a hook fails, and it returns the
+// errors of all failed hooks. A failed reload never stops the application.
+// Stop and Restart wait for a reload in progress to finish before running
+// any OnStop hooks.
+//
+// If any hooks were appended with OnReload callbacks, a started application
+// also reloads when it receives SIGHUP, using StartTimeout to bound each
+// reload. SIGHUP keeps its default behavior for applications without such
+// hooks, or if it's one of the signals passed to ShutdownSignals.
+//
+// Reload emits an fxevent.Reloading event before the hooks run, and an
+// fxevent.Reloaded event after they finish.
+func (app *App) Reload(ctx context.Context) error {
+	return app.reload(ctx, nil)
+}
+
+func (app *App) reload(ctx context.Context, sig os.Signal) (err error) {
+	app.log().LogEvent(&fxevent.Reloading{Signal: sig})
+	defer func() {
+		app.log().LogEvent(&fxevent.Reloaded{Err: err})
+	}()
+
+	if app.err != nil {
+		return app.err
+	}
+
+	// Tell systemd the reload is over even if it failed, since the
+	// application keeps running either way.
+	if app.State() == StateStarted {
+		app.sdNotify(sdnotify.Reloading)
+		defer app.sdNotify(sdnotify.Ready)
+	}
+
+	return withTimeout(ctx, &withTimeoutParams{
+		hook:      _onReloadHook,
+		callback:  app.lifecycle.Reload,
+		lifecycle: app.lifecycle,
+		log:       app.log(),
 	})
 }
 
+func (app *App) reloadOnSignal(sig os.Signal) {
+	ctx, cancel := app.clock.WithTimeout(context.Background(), app.StartTimeout())
+	defer cancel()
+
+	// Failures are reported with the fxevent.Reloaded event.
+	_ = app.reload(ctx, sig)
+}
+
+func (app *App) restart(ctx context.Context) error {
+	// Stopping the signal relay keeps the channels returned by Done and
+	// Wait. Starting the application starts a fresh relay for them.
+	err := multierr.Append(
+		app.lifecycle.Stop(ctx),
+		app.receivers.Stop(ctx),
+	)
+	app.traceHooks(app.lifecycle.StopRecords)
+	if err != nil {
+		return err
+	}
+
+	err = app.start(ctx)
+	app.traceHooks(app.lifecycle.StartRecords)
+	return err
+}
+
 // Done returns a channel of signals to block on after starting the
 // application. Applications listen for the SIGINT and SIGTERM signals; during
 // development, users can send the application SIGTERM by pressing Ctrl-C in
@@ -759,6 +1205,10 @@ type withTimeoutParams struct {
 	hook      string
 	callback  func(context.Context) error
 	lifecycle *lifecycleWrapper
+
+	// Whether to wait for the callback even after ctx ends. The callback
+	// must then bound its own running time.
+	wait bool
 }
 
 // errHookCallbackExited is returned when a hook callback does not finish executing
@@ -782,6 +1232,10 @@ func withTimeout(ctx context.Context, param *withTimeoutParams) error {
 		callbackExited = true
 	}()
 
+	if param.wait {
+		return <-c
+	}
+
 	var err error
 
 	select {
@@ -789,13 +1243,22 @@ func withTimeout(ctx context.Context, param *withTimeoutParams) error {
 		err = ctx.Err()
 	case err = <-c:
 		// If the context finished at the same time as the callback
-		// prefer the context error.
+		// prefer the context error, unless the callback already
+		// identified the hook that timed out.
 		// This eliminates non-determinism in select-case selection.
-		if ctx.Err() != nil {
+		var timeoutErr *HookTimeoutError
+		if ctx.Err() != nil && !errors.As(err, &timeoutErr) {
 			err = ctx.Err()
 		}
 	}
 
+	// Identify the hook that was still running when the deadline passed.
+	if err == context.DeadlineExceeded {
+		if timeoutErr := param.lifecycle.TimeoutError(ctx); timeoutErr != nil {
+			err = timeoutErr
+		}
+	}
+
 	return err
 }
 
@@ -21,10 +21,11 @@
 package fx
 
 import (
+	"context"
 	"fmt"
-	"os"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -41,25 +42,30 @@ func TestAppRun(t *testing.T) {
 	app := New(
 		WithLogger(func() fxevent.Logger { return spy }),
 	)
-	done := make(chan os.Signal)
+	wait := make(chan ShutdownSignal)
 
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		app.run(done)
+		app.run(context.Background(), wait)
 	}()
 
-	done <- _sigINT
+	wait <- ShutdownSignal{Signal: _sigINT}
 	wg.Wait()
 
 	assert.Equal(t, []string{
+		"Provided",
+		"Provided",
+		"Provided",
 		"Provided",
 		"Provided",
 		"Provided",
 		"LoggerInitialized",
+		"StateChanged", "StateChanged",
 		"Started",
 		"Stopping",
+		"StateChanged", "StateChanged",
 		"Stopped",
 	}, spy.EventTypes())
 }
@@ -73,6 +79,75 @@ func TestValidateString(t *testing.T) {
 	assert.Equal(t, "fx.validate(true)", stringer.String())
 }
 
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		desc   string
+		policy RetryPolicy
+		want   []time.Duration // backoff for attempts 1, 2, ...; stops after
+	}{
+		{
+			desc:   "defaults",
+			policy: RetryPolicy{MaxAttempts: 4},
+			want: []time.Duration{
+				100 * time.Millisecond,
+				200 * time.Millisecond,
+				400 * time.Millisecond,
+			},
+		},
+		{
+			desc: "capped",
+			policy: RetryPolicy{
+				MaxAttempts:    5,
+				InitialBackoff: time.Second,
+				MaxBackoff:     3 * time.Second,
+				Multiplier:     2,
+			},
+			want: []time.Duration{
+				time.Second,
+				2 * time.Second,
+				3 * time.Second,
+				3 * time.Second,
+			},
+		},
+		{
+			desc:   "single attempt",
+			policy: RetryPolicy{MaxAttempts: 1},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var got []time.Duration
+			for attempt := 1; ; attempt++ {
+				d, ok := tt.policy.Backoff(attempt)
+				if !ok {
+					break
+				}
+				got = append(got, d)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("jitter", func(t *testing.T) {
+		t.Parallel()
+
+		p := RetryPolicy{InitialBackoff: time.Second, Jitter: 0.5}
+		for i := 0; i < 100; i++ {
+			d, ok := p.Backoff(1)
+			require.True(t, ok, "unlimited attempts must always retry")
+			assert.GreaterOrEqual(t, d, 500*time.Millisecond)
+			assert.LessOrEqual(t, d, 1500*time.Millisecond)
+		}
+	})
+}
+
 // WithExit is an internal option available only to tests defined in this
 // package. It changes how os.Exit behaves for the application.
 func WithExit(f func(int)) Option {
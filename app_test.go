@@ -30,6 +30,8 @@ import (
 	"os"
 	"reflect"
 	"runtime"
+	"sync"
+	"syscall"
 	"testing"
 	"time"
 
@@ -37,6 +39,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"go.uber.org/atomic"
 	. "go.uber.org/fx"
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/fx/fxtest"
@@ -108,7 +111,7 @@ func TestNewApp(t *testing.T) {
 			WithLogger(func() fxevent.Logger { return spy }))
 		defer app.RequireStart().RequireStop()
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "Provided", "LoggerInitialized", "Started"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "LoggerInitialized", "StateChanged", "StateChanged", "Started"},
 			spy.EventTypes())
 
 		assert.Contains(t, spy.Events()[0].(*fxevent.Provided).OutputTypeNames, "struct {}")
@@ -357,7 +360,7 @@ func TestNewApp(t *testing.T) {
 		defer app.RequireStart().RequireStop()
 
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "Provided", "Decorated", "LoggerInitialized", "Invoking", "Invoked", "Started"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Decorated", "LoggerInitialized", "Invoking", "Run", "Run", "Invoked", "StateChanged", "StateChanged", "Started"},
 			spy.EventTypes())
 	})
 
@@ -378,7 +381,7 @@ func TestNewApp(t *testing.T) {
 		defer app.RequireStart().RequireStop()
 
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "Provided", "Decorated", "Decorated", "LoggerInitialized", "Started"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Decorated", "Decorated", "LoggerInitialized", "StateChanged", "StateChanged", "Started"},
 			spy.EventTypes())
 	})
 }
@@ -573,7 +576,7 @@ func TestWithLogger(t *testing.T) {
 		)
 
 		assert.Equal(t, []string{
-			"Supplied", "Provided", "Provided", "Provided", "LoggerInitialized",
+			"Supplied", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Run", "LoggerInitialized",
 		}, spy.EventTypes())
 
 		spy.Reset()
@@ -581,7 +584,7 @@ func TestWithLogger(t *testing.T) {
 
 		require.NoError(t, app.Err())
 
-		assert.Equal(t, []string{"Started", "Stopped"}, spy.EventTypes())
+		assert.Equal(t, []string{"StateChanged", "StateChanged", "Started", "StateChanged", "StateChanged", "Stopped"}, spy.EventTypes())
 	})
 
 	t.Run("error in Provide shows logs", func(t *testing.T) {
@@ -603,7 +606,7 @@ func TestWithLogger(t *testing.T) {
 			"must provide constructor function, got  (type *bytes.Buffer)",
 		)
 
-		assert.Equal(t, []string{"Supplied", "Provided", "LoggerInitialized"}, spy.EventTypes())
+		assert.Equal(t, []string{"Supplied", "Provided", "Run", "LoggerInitialized"}, spy.EventTypes())
 	})
 
 	t.Run("logger failed to build", func(t *testing.T) {
@@ -1051,6 +1054,321 @@ func TestAppRunTimeout(t *testing.T) {
 	}
 }
 
+func TestAppRunExitCode(t *testing.T) {
+	t.Parallel()
+
+	errCause := errors.New("great sadness")
+	tests := []struct {
+		desc       string
+		giveOpts   []ShutdownOption
+		giveStop   error
+		wantExited bool
+		wantCode   int
+		wantCause  error
+	}{
+		{desc: "no options"},
+		{
+			desc:       "exit code",
+			giveOpts:   []ShutdownOption{ExitCode(3)},
+			wantExited: true,
+			wantCode:   3,
+		},
+		{
+			desc:       "cause",
+			giveOpts:   []ShutdownOption{ShutdownCause(errCause)},
+			wantExited: true,
+			wantCode:   1,
+			wantCause:  errCause,
+		},
+		{
+			desc:       "exit code and cause",
+			giveOpts:   []ShutdownOption{ExitCode(3), ShutdownCause(errCause)},
+			wantExited: true,
+			wantCode:   3,
+			wantCause:  errCause,
+		},
+		{
+			desc:       "exit code and stop error",
+			giveOpts:   []ShutdownOption{ExitCode(3)},
+			giveStop:   errors.New("stop failed"),
+			wantExited: true,
+			wantCode:   3,
+		},
+		{
+			desc:       "stop error",
+			giveStop:   errors.New("stop failed"),
+			wantExited: true,
+			wantCode:   1,
+		},
+		{
+			desc:       "stop error with exit code",
+			giveStop:   WithExitCode(errors.New("stop failed"), 5),
+			wantExited: true,
+			wantCode:   5,
+		},
+		{
+			desc:       "cause with exit code",
+			giveOpts:   []ShutdownOption{ShutdownCause(WithExitCode(errCause, 5))},
+			wantExited: true,
+			wantCode:   5,
+			wantCause:  WithExitCode(errCause, 5),
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				exitCode int
+				exited   bool
+			)
+			app, spy := NewSpied(
+				WithExit(func(code int) {
+					exited = true
+					exitCode = code
+				}),
+				Invoke(func(sd Shutdowner, lc Lifecycle) {
+					lc.Append(Hook{
+						OnStart: func(context.Context) error {
+							return sd.Shutdown(tt.giveOpts...)
+						},
+						OnStop: func(context.Context) error {
+							return tt.giveStop
+						},
+					})
+				}),
+			)
+
+			app.Run()
+			assert.Equal(t, tt.wantExited, exited, "os.Exit call mismatch")
+			assert.Equal(t, tt.wantCode, exitCode, "exit code mismatch")
+
+			stopping := spy.Events().SelectByTypeName("Stopping")
+			require.Len(t, stopping, 1)
+			assert.Equal(t, tt.wantCause, stopping[0].(*fxevent.Stopping).Cause)
+		})
+	}
+}
+
+func TestAppRunContext(t *testing.T) {
+	t.Parallel()
+
+	// exitCode returns the exit code carried by err, or 0 if none.
+	exitCode := func(err error) int {
+		var exit interface{ ExitCode() int }
+		if errors.As(err, &exit) {
+			return exit.ExitCode()
+		}
+		return 0
+	}
+
+	t.Run("CancelContext", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped bool
+		app, spy := NewSpied(
+			WithExit(func(int) { assert.Fail(t, "must not exit") }),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(StopHook(func() { stopped = true }))
+			}),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errc := make(chan error, 1)
+		go func() { errc <- app.RunContext(ctx) }()
+
+		require.Eventually(t, func() bool {
+			return app.State() == StateStarted
+		}, time.Second, time.Millisecond)
+		cancel()
+
+		assert.NoError(t, <-errc)
+		assert.True(t, stopped, "OnStop hook must run")
+		assert.Empty(t, spy.Events().SelectByTypeName("Stopping"))
+		assert.Len(t, spy.Events().SelectByTypeName("Stopped"), 1)
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			WithExit(func(int) { assert.Fail(t, "must not exit") }),
+			Invoke(func(sd Shutdowner, lc Lifecycle) {
+				lc.Append(StartHook(func() error {
+					return sd.Shutdown(ExitCode(3))
+				}))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		assert.EqualError(t, err, "shut down with exit code 3")
+		assert.Equal(t, 3, exitCode(err))
+	})
+
+	t.Run("CauseAndStopError", func(t *testing.T) {
+		t.Parallel()
+
+		errCause := errors.New("great sadness")
+		errStop := errors.New("stop failed")
+		app := New(
+			NopLogger,
+			Invoke(func(sd Shutdowner, lc Lifecycle) {
+				lc.Append(StartStopHook(
+					func() error { return sd.Shutdown(ShutdownCause(errCause)) },
+					func() error { return errStop },
+				))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		assert.ErrorIs(t, err, errCause)
+		assert.ErrorIs(t, err, errStop)
+		assert.Equal(t, 1, exitCode(err))
+	})
+
+	t.Run("StartError", func(t *testing.T) {
+		t.Parallel()
+
+		errStart := errors.New("great sadness")
+		app := New(
+			NopLogger,
+			WithExit(func(int) { assert.Fail(t, "must not exit") }),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(StartHook(func() error {
+					return WithExitCode(errStart, 69)
+				}))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		assert.ErrorIs(t, err, errStart)
+		assert.Equal(t, 69, exitCode(err))
+	})
+
+	t.Run("NewError", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(NopLogger, Invoke(func(*bytes.Buffer) {}))
+		err := app.RunContext(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "missing type: *bytes.Buffer")
+		assert.Equal(t, 1, exitCode(err))
+	})
+}
+
+func TestAppRunStartExitCode(t *testing.T) {
+	t.Parallel()
+
+	errConfig := errors.New("invalid config")
+	type A struct{}
+
+	tests := []struct {
+		desc     string
+		give     []Option
+		wantCode int
+	}{
+		{
+			desc: "constructor error",
+			give: []Option{
+				Provide(func() (*A, error) {
+					return nil, WithExitCode(errConfig, 78)
+				}),
+				Invoke(func(*A) {}),
+			},
+			wantCode: 78,
+		},
+		{
+			desc: "invoke error",
+			give: []Option{
+				Invoke(func() error {
+					return fmt.Errorf("load: %w", WithExitCode(errConfig, 78))
+				}),
+			},
+			wantCode: 78,
+		},
+		{
+			desc: "hook error",
+			give: []Option{
+				Invoke(func(lc Lifecycle) {
+					lc.Append(StartHook(func() error {
+						return WithExitCode(errConfig, 69)
+					}))
+				}),
+			},
+			wantCode: 69,
+		},
+		{
+			desc: "New error",
+			give: []Option{
+				Invoke(func(*A) {}),
+			},
+			wantCode: 1,
+		},
+		{
+			desc: "mapper",
+			give: []Option{
+				ExitCodeMapper(func(err error) int {
+					if errors.Is(err, errConfig) {
+						return 78
+					}
+					return 0
+				}),
+				Invoke(func() error { return errConfig }),
+			},
+			wantCode: 78,
+		},
+		{
+			desc: "mapper without opinion",
+			give: []Option{
+				ExitCodeMapper(func(error) int { return 0 }),
+				Invoke(func() error { return WithExitCode(errConfig, 69) }),
+			},
+			wantCode: 69,
+		},
+		{
+			desc: "mapper for New error",
+			give: []Option{
+				ExitCodeMapper(func(error) int { return 70 }),
+				Invoke(func(*A) {}),
+			},
+			wantCode: 70,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			var exitCode int
+			app := New(append([]Option{
+				NopLogger,
+				WithExit(func(code int) { exitCode = code }),
+			}, tt.give...)...)
+
+			app.Run()
+			assert.Equal(t, tt.wantCode, exitCode)
+		})
+	}
+
+	t.Run("nil error", func(t *testing.T) {
+		t.Parallel()
+
+		assert.NoError(t, WithExitCode(nil, 2))
+	})
+
+	t.Run("in module", func(t *testing.T) {
+		t.Parallel()
+
+		err := New(NopLogger, Module("foo", ExitCodeMapper(func(error) int { return 2 }))).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.ExitCodeMapper Option should be passed to top-level App")
+	})
+}
+
 func TestAppStart(t *testing.T) {
 	t.Parallel()
 
@@ -1087,7 +1405,15 @@ func TestAppStart(t *testing.T) {
 
 		err := app.Start(ctx)
 		require.Error(t, err)
-		assert.Contains(t, "context deadline exceeded", err.Error())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStart", timeoutErr.Method)
+		assert.Contains(t, timeoutErr.Caller, "TestAppStart")
+		assert.Equal(t, time.Second, timeoutErr.Timeout)
+		assert.Empty(t, timeoutErr.Completed)
+		assert.Empty(t, timeoutErr.Stack, "stack must only be recorded if requested")
 		cancel()
 	})
 
@@ -1150,7 +1476,68 @@ func TestAppStart(t *testing.T) {
 
 		err := app.Start(ctx)
 		require.Error(t, err)
-		assert.Contains(t, "context deadline exceeded", err.Error())
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStart", timeoutErr.Method)
+		assert.Len(t, timeoutErr.Completed, 2, "hooks of A and B must have completed")
+	})
+
+	t.Run("ConcurrentTimeout", func(t *testing.T) {
+		t.Parallel()
+
+		type A struct{}
+		type B struct{}
+
+		// A's hook hangs. B's hook runs alongside it, and its retry
+		// begins after A's hook and finishes first, which must not
+		// hide A's hook from the timeout error.
+		aRunning := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		newA := func(lc Lifecycle) *A {
+			lc.Append(Hook{
+				OnStart: func(context.Context) error {
+					close(aRunning)
+					<-release // ignores the context
+					return nil
+				},
+			})
+			return &A{}
+		}
+		newB := func(lc Lifecycle) *B {
+			var attempts int
+			lc.Append(Hook{
+				OnStart: func(context.Context) error {
+					<-aRunning
+					if attempts++; attempts == 1 {
+						return errors.New("great sadness")
+					}
+					return nil
+				},
+				StartRetry: &RetryPolicy{InitialBackoff: time.Millisecond},
+			})
+			return &B{}
+		}
+
+		spy := new(fxlog.Spy)
+		app := New(
+			WithLogger(func() fxevent.Logger { return spy }),
+			ConcurrentLifecycle(),
+			Provide(newA, newB),
+			Invoke(func(*A, *B) {}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := app.Start(ctx)
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStart", timeoutErr.Method)
+		assert.Contains(t, timeoutErr.FunctionName, "TestAppStart.func3.1")
+		assert.Len(t, timeoutErr.Completed, 1, "hook of B must have completed")
 	})
 
 	t.Run("CtxCancelledDuringStart", func(t *testing.T) {
@@ -1211,12 +1598,15 @@ func TestAppStart(t *testing.T) {
 		assert.Contains(t, err.Error(), "OnStart fail")
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized",
-			"Invoking",
+			"Invoking", "Run", "Run",
 			"Invoked",
+			"StateChanged",
 			"OnStartExecuting", "OnStartExecuted",
+			"StateChanged",
 			"RollingBack",
+			"StateChanged", "StateChanged",
 			"RolledBack",
 			"Started",
 		}, spy.EventTypes())
@@ -1247,14 +1637,18 @@ func TestAppStart(t *testing.T) {
 		assert.Equal(t, []error{errStart2, errStop1}, multierr.Errors(err))
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized",
-			"Invoking",
+			"Invoking", "Run", "Run",
 			"Invoked",
+			"StateChanged",
 			"OnStartExecuting", "OnStartExecuted",
 			"OnStartExecuting", "OnStartExecuted",
+			"StateChanged",
 			"RollingBack",
+			"StateChanged",
 			"OnStopExecuting", "OnStopExecuted",
+			"StateChanged",
 			"RolledBack",
 			"Started",
 		}, spy.EventTypes())
@@ -1278,7 +1672,7 @@ func TestAppStart(t *testing.T) {
 		//         /.../go/1.13.3/libexec/src/testing/testing.go:909
 		// Failed: can't invoke non-function {} (type struct {})
 		require.Equal(t,
-			[]string{"Provided", "Provided", "Provided", "LoggerInitialized", "Invoking", "Invoked"},
+			[]string{"Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "LoggerInitialized", "Invoking", "Invoked"},
 			spy.EventTypes())
 		failedEvent := spy.Events()[len(spy.EventTypes())-1].(*fxevent.Invoked)
 		assert.Contains(t, failedEvent.Err.Error(), "can't invoke non-function")
@@ -1335,149 +1729,893 @@ func TestAppStart(t *testing.T) {
 		require.Error(t, err, "expected start failure")
 		assert.Equal(t, err, newErr, "start should return the same error fx.New encountered")
 
-		// Example
-		// fx.Option should be passed to fx.New directly, not to fx.Invoke: fx.Invoke received fx.Invoke(go.uber.org/fx_test.TestAppStart.func6.2()) from:
-		// go.uber.org/fx_test.TestAppStart.func6
-		//         /.../fx/app_test.go:579
-		// testing.tRunner
-		//         /.../go/1.13.3/libexec/src/testing/testing.go:909
-		assert.Contains(t, err.Error(), "fx.Option should be passed to fx.New directly, not to fx.Invoke")
-		assert.Contains(t, err.Error(), "fx.Invoke received fx.Invoke(go.uber.org/fx_test.TestAppStart")
-		assert.Contains(t, err.Error(), "go.uber.org/fx_test.TestAppStart")
-		assert.Contains(t, err.Error(), "/app_test.go")
+		// Example
+		// fx.Option should be passed to fx.New directly, not to fx.Invoke: fx.Invoke received fx.Invoke(go.uber.org/fx_test.TestAppStart.func6.2()) from:
+		// go.uber.org/fx_test.TestAppStart.func6
+		//         /.../fx/app_test.go:579
+		// testing.tRunner
+		//         /.../go/1.13.3/libexec/src/testing/testing.go:909
+		assert.Contains(t, err.Error(), "fx.Option should be passed to fx.New directly, not to fx.Invoke")
+		assert.Contains(t, err.Error(), "fx.Invoke received fx.Invoke(go.uber.org/fx_test.TestAppStart")
+		assert.Contains(t, err.Error(), "go.uber.org/fx_test.TestAppStart")
+		assert.Contains(t, err.Error(), "/app_test.go")
+	})
+
+	t.Run("ProvidingOptionsShouldFail", func(t *testing.T) {
+		t.Parallel()
+
+		type type1 struct{}
+		type type2 struct{}
+		type type3 struct{}
+
+		module := Options(
+			Provide(
+				func() type1 { return type1{} },
+				func() type2 { return type2{} },
+			),
+			Invoke(func(type1) {
+				require.FailNow(t, "module Invoked must not be called")
+			}),
+		)
+
+		app := NewForTest(t,
+			Provide(
+				func() type3 { return type3{} },
+				module,
+			),
+		)
+		err := app.Err()
+		require.Error(t, err, "expected start failure")
+
+		// Example:
+		// fx.Annotated should be passed to fx.Provide directly, it should not be returned by the constructor: fx.Provide received go.uber.org/fx_test.TestAnnotatedWrongUsage.func2.1() from:
+		// go.uber.org/fx_test.TestAnnotatedWrongUsage.func2
+		//         /.../fx/annotated_test.go:76
+		// testing.tRunner
+		//         /.../go/1.13.3/libexec/src/testing/testing.go:909
+		assert.Contains(t, err.Error(), "fx.Option should be passed to fx.New directly, not to fx.Provide")
+		assert.Contains(t, err.Error(), "fx.Provide received fx.Options(fx.Provide(go.uber.org/fx_test.TestAppStart")
+		assert.Contains(t, err.Error(), "go.uber.org/fx_test.TestAppStart")
+		assert.Contains(t, err.Error(), "/app_test.go")
+	})
+
+	t.Run("HookGoroutineExitsErrorMsg", func(t *testing.T) {
+		t.Parallel()
+
+		addHook := func(lc Lifecycle) {
+			lc.Append(Hook{
+				OnStart: func(ctx context.Context) error {
+					runtime.Goexit()
+					return nil
+				},
+			})
+		}
+		app := fxtest.New(t,
+			Invoke(addHook),
+		)
+		err := app.Start(context.Background()).Error()
+		assert.Contains(t, "goroutine exited without returning", err)
+	})
+
+	t.Run("StartTwiceWithHooksErrors", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		app := fxtest.New(t,
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(ctx context.Context) error { return nil },
+					OnStop:  func(ctx context.Context) error { return nil },
+				})
+			}),
+		)
+		assert.NoError(t, app.Start(ctx))
+		err := app.Start(ctx)
+		if assert.Error(t, err) {
+			assert.ErrorContains(t, err, "attempted to start lifecycle when in state: started")
+		}
+		app.Stop(ctx)
+		assert.NoError(t, app.Start(ctx))
+		app.Stop(ctx)
+	})
+}
+
+func TestAppStop(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		mockClock := clock.NewMock()
+
+		block := func(ctx context.Context) error {
+			mockClock.Add(5 * time.Second)
+			return ctx.Err()
+		}
+		// NOTE: for tests that gets cancelled/times out during lifecycle methods, it's possible
+		// for them to run into race with fxevent logs getting written to testing.T with the
+		// remainder of the tests. As a workaround, we provide fxlog.Spy to prevent the lifecycle
+		// goroutine from writing to testing.T.
+		spy := new(fxlog.Spy)
+		app := New(Invoke(func(l Lifecycle) { l.Append(Hook{OnStop: block}) }),
+			WithLogger(func() fxevent.Logger { return spy }),
+			WithClock(mockClock),
+		)
+
+		err := app.Start(context.Background())
+		require.Nil(t, err)
+
+		ctx, cancel := mockClock.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		err = app.Stop(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "context deadline exceeded")
+	})
+
+	t.Run("StopError", func(t *testing.T) {
+		t.Parallel()
+
+		failStop := func(lc Lifecycle) struct{} {
+			lc.Append(Hook{OnStop: func(context.Context) error {
+				return errors.New("OnStop fail")
+			}})
+			return struct{}{}
+		}
+		app := fxtest.New(t,
+			Provide(failStop),
+			Invoke(func(struct{}) {}),
+		)
+		app.RequireStart()
+		err := app.Stop(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "OnStop fail")
+	})
+}
+
+func TestAppRestart(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RunsHooksAgain", func(t *testing.T) {
+		t.Parallel()
+
+		var calls []string
+		app, spy := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						calls = append(calls, "start")
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						calls = append(calls, "stop")
+						return nil
+					},
+				})
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		require.NoError(t, app.Restart(ctx))
+		require.NoError(t, app.Stop(ctx))
+
+		assert.Equal(t, []string{"start", "stop", "start", "stop"}, calls)
+		assert.Len(t, spy.Events().SelectByTypeName("Restarting"), 1)
+		restarted := spy.Events().SelectByTypeName("Restarted")
+		require.Len(t, restarted, 1)
+		assert.NoError(t, restarted[0].(*fxevent.Restarted).Err)
+	})
+
+	t.Run("KeepsHooksAppendedWhileRunning", func(t *testing.T) {
+		t.Parallel()
+
+		var starts int
+		app, _ := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						if starts == 0 {
+							lc.Append(Hook{
+								OnStart: func(context.Context) error {
+									starts++
+									return nil
+								},
+							})
+						}
+						starts++
+						return nil
+					},
+				})
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		assert.Equal(t, 1, starts, "hook appended during start must not run in that start")
+
+		require.NoError(t, app.Restart(ctx))
+		assert.Equal(t, 3, starts, "hook appended during start must run on restart")
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	t.Run("DoneAndWaitSurvive", func(t *testing.T) {
+		t.Parallel()
+
+		var s Shutdowner
+		app, _ := NewSpied(Populate(&s))
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		done := app.Done()
+		wait := app.Wait()
+
+		require.NoError(t, app.Restart(ctx))
+		require.NoError(t, s.Shutdown(ExitCode(3)))
+
+		assert.NotNil(t, <-done)
+		assert.Equal(t, 3, (<-wait).ExitCode)
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	t.Run("StopError", func(t *testing.T) {
+		t.Parallel()
+
+		var starts int
+		app, spy := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						starts++
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						return errors.New("great sadness")
+					},
+				})
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		err := app.Restart(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "great sadness")
+		assert.Equal(t, 1, starts, "app must not start after a failed stop")
+
+		restarted := spy.Events().SelectByTypeName("Restarted")
+		require.Len(t, restarted, 1)
+		assert.Error(t, restarted[0].(*fxevent.Restarted).Err)
+	})
+
+	t.Run("StartErrorRollsBack", func(t *testing.T) {
+		t.Parallel()
+
+		var starts, stops int
+		app, spy := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStop: func(context.Context) error {
+						stops++
+						return nil
+					},
+				})
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						starts++
+						if starts > 1 {
+							return errors.New("great sadness")
+						}
+						return nil
+					},
+				})
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		err := app.Restart(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "great sadness")
+		assert.Equal(t, 2, stops, "restart must stop, then roll back")
+		assert.Len(t, spy.Events().SelectByTypeName("RolledBack"), 1)
+	})
+}
+
+func TestHookTimeout(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	var stopped atomic.Bool
+	app, spy := NewSpied(
+		Invoke(func(lc Lifecycle) {
+			lc.Append(Hook{
+				OnStop: func(context.Context) error {
+					stopped.Store(true)
+					return nil
+				},
+			})
+			lc.Append(Hook{
+				OnStart: func(context.Context) error {
+					<-release
+					return nil
+				},
+				Timeout: time.Millisecond,
+			})
+		}),
+	)
+
+	err := app.Start(context.Background())
+	require.Error(t, err)
+
+	var timeoutErr *HookTimeoutError
+	require.ErrorAs(t, err, &timeoutErr)
+	assert.Equal(t, time.Millisecond, timeoutErr.Timeout)
+	assert.True(t, stopped.Load(), "start failure must roll back")
+	assert.Len(t, spy.Events().SelectByTypeName("RollingBack"), 1)
+}
+
+func TestAppReload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("RunsHooksInOrder", func(t *testing.T) {
+		t.Parallel()
+
+		var reloaded []string
+		app, spy := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{OnReload: func(context.Context) error {
+					reloaded = append(reloaded, "a")
+					return nil
+				}})
+				lc.Append(ReloadHook(func() {
+					reloaded = append(reloaded, "b")
+				}))
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		require.NoError(t, app.Reload(ctx))
+		require.NoError(t, app.Reload(ctx))
+		require.NoError(t, app.Stop(ctx))
+
+		assert.Equal(t, []string{"a", "b", "a", "b"}, reloaded)
+		assert.Len(t, spy.Events().SelectByTypeName("Reloading"), 2)
+		assert.Len(t, spy.Events().SelectByTypeName("Reloaded"), 2)
+	})
+
+	t.Run("FailureKeepsRunning", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(ReloadHook(func() error {
+					return errors.New("great sadness")
+				}))
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		done := app.Done()
+
+		err := app.Reload(ctx)
+		assert.EqualError(t, err, "great sadness")
+		assert.Equal(t, StateStarted, app.State())
+		select {
+		case sig := <-done:
+			assert.Fail(t, "reload failure must not shut down", "got %v", sig)
+		default:
+		}
+
+		reloaded := spy.Events().SelectByTypeName("Reloaded")
+		require.Len(t, reloaded, 1)
+		assert.EqualError(t, reloaded[0].(*fxevent.Reloaded).Err, "great sadness")
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	t.Run("NotStarted", func(t *testing.T) {
+		t.Parallel()
+
+		app, _ := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(ReloadHook(func() {}))
+			}),
+		)
+
+		err := app.Reload(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "attempted to reload lifecycle when in state: stopped")
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		app, _ := NewSpied(
+			Invoke(func(lc Lifecycle) {
+				lc.Append(ReloadHook(func() {
+					<-release
+				}))
+			}),
+		)
+
+		require.NoError(t, app.Start(context.Background()))
+		defer app.Stop(context.Background())
+		defer close(release)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, app.Reload(ctx), &timeoutErr)
+		assert.Equal(t, "OnReload", timeoutErr.Method)
+	})
+}
+
+func TestDumpGoroutinesOnTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("StartDeadline", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		type A struct{}
+		app, spy := NewSpied(
+			DumpGoroutinesOnTimeout(),
+			Module("blocker",
+				Provide(func(lc Lifecycle) *A {
+					lc.Append(Hook{OnStart: func(context.Context) error {
+						<-release // ignores the context
+						return nil
+					}})
+					return &A{}
+				}),
+			),
+			Invoke(func(*A) {}),
+		)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		err := app.Start(ctx)
+		require.Error(t, err)
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "blocker", timeoutErr.Module)
+		assert.Contains(t, err.Error(), `in module "blocker"`)
+		assert.Contains(t, timeoutErr.Stack, "TestDumpGoroutinesOnTimeout",
+			"stack must include the blocked hook")
+
+		dumps := spy.Events().SelectByTypeName("GoroutineDump")
+		require.Len(t, dumps, 1)
+		assert.Equal(t, timeoutErr.Stack, dumps[0].(*fxevent.GoroutineDump).Stack)
+	})
+
+	t.Run("ModuleOptionFails", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t, Module("foo", DumpGoroutinesOnTimeout()))
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.DumpGoroutinesOnTimeout Option should be passed to top-level App")
+	})
+}
+
+func TestStopPolicy(t *testing.T) {
+	t.Parallel()
+
+	newApp := func(t *testing.T, opts ...Option) (app *App, stopped *[]string) {
+		stopped = new([]string)
+		opts = append(opts,
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{OnStop: func(ctx context.Context) error {
+					assert.NoError(t, ctx.Err(), "hook must get a fresh context")
+					*stopped = append(*stopped, "first")
+					return nil
+				}})
+				lc.Append(Hook{OnStop: func(context.Context) error {
+					*stopped = append(*stopped, "second")
+					return errors.New("great sadness")
+				}})
+			}),
+		)
+		app = NewForTest(t, opts...)
+		require.NoError(t, app.Start(context.Background()))
+		return app, stopped
+	}
+
+	expired := func(t *testing.T) context.Context {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	t.Run("AbortAtDeadline", func(t *testing.T) {
+		t.Parallel()
+
+		app, stopped := newApp(t)
+		err := app.Stop(expired(t))
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Empty(t, *stopped)
+	})
+
+	t.Run("ContinueAfterDeadline", func(t *testing.T) {
+		t.Parallel()
+
+		app, stopped := newApp(t, StopPolicy(ContinueAfterDeadline))
+		err := app.Stop(expired(t))
+		assert.EqualError(t, err, "great sadness")
+		assert.Equal(t, []string{"second", "first"}, *stopped)
+	})
+
+	t.Run("UnknownPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t, StopPolicy(DeadlinePolicy(42)))
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.StopPolicy received unknown policy fx.DeadlinePolicy(42)")
+	})
+
+	t.Run("ModuleOptionFails", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t, Module("foo", StopPolicy(ContinueAfterDeadline)))
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.StopPolicy Option should be passed to top-level App")
+	})
+}
+
+func TestSlowHookThreshold(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReportsSlowHooks", func(t *testing.T) {
+		t.Parallel()
+
+		var spy *fxlog.Spy
+		app, spy := NewSpied(
+			SlowHookThreshold(time.Millisecond),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{OnStart: func(context.Context) error {
+					assert.Eventually(t, func() bool {
+						return len(spy.Events().SelectByTypeName("OnStartSlow")) > 0
+					}, time.Second, time.Millisecond)
+					return nil
+				}})
+			}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		require.NoError(t, app.Stop(context.Background()))
+	})
+
+	t.Run("NonPositive", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t, SlowHookThreshold(0))
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.SlowHookThreshold must be positive")
+	})
+
+	t.Run("ModuleOptionFails", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t, Module("foo", SlowHookThreshold(time.Second)))
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.SlowHookThreshold Option should be passed to top-level App")
+	})
+}
+
+func TestConcurrentLifecycle(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+	type C struct{}
+
+	t.Run("IndependentHooksRunConcurrently", func(t *testing.T) {
+		t.Parallel()
+
+		// Each hook waits for the other one to start, so this app can
+		// only start if the hooks run concurrently.
+		aStarted := make(chan struct{})
+		bStarted := make(chan struct{})
+		app := fxtest.New(t,
+			ConcurrentLifecycle(),
+			Provide(
+				func(lc Lifecycle) *A {
+					lc.Append(Hook{OnStart: func(ctx context.Context) error {
+						close(aStarted)
+						<-bStarted
+						return nil
+					}})
+					return &A{}
+				},
+				func(lc Lifecycle) *B {
+					lc.Append(Hook{OnStart: func(ctx context.Context) error {
+						close(bStarted)
+						<-aStarted
+						return nil
+					}})
+					return &B{}
+				},
+			),
+			Invoke(func(*A, *B) {}),
+		)
+		app.RequireStart().RequireStop()
+	})
+
+	t.Run("DependencyOrder", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu     sync.Mutex
+			events []string
+		)
+		record := func(ev string) func(context.Context) error {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, ev)
+				return nil
+			}
+		}
+
+		app := fxtest.New(t,
+			ConcurrentLifecycle(),
+			Provide(
+				func(lc Lifecycle) *A {
+					lc.Append(Hook{OnStart: record("start A"), OnStop: record("stop A")})
+					return &A{}
+				},
+				func(lc Lifecycle, _ *A) *B {
+					lc.Append(Hook{OnStart: record("start B"), OnStop: record("stop B")})
+					return &B{}
+				},
+				Annotate(
+					func(*B) *C { return &C{} },
+					OnStart(record("start C")),
+					OnStop(record("stop C")),
+				),
+			),
+			Invoke(func(*C) {}),
+		)
+		app.RequireStart().RequireStop()
+
+		assert.Equal(t, []string{
+			"start A", "start B", "start C",
+			"stop C", "stop B", "stop A",
+		}, events)
 	})
 
-	t.Run("ProvidingOptionsShouldFail", func(t *testing.T) {
+	t.Run("DecoratorDependencyOrder", func(t *testing.T) {
 		t.Parallel()
 
-		type type1 struct{}
-		type type2 struct{}
-		type type3 struct{}
-
-		module := Options(
-			Provide(
-				func() type1 { return type1{} },
-				func() type2 { return type2{} },
-			),
-			Invoke(func(type1) {
-				require.FailNow(t, "module Invoked must not be called")
-			}),
+		var (
+			mu     sync.Mutex
+			events []string
 		)
+		record := func(ev string) func(context.Context) error {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, ev)
+				return nil
+			}
+		}
 
-		app := NewForTest(t,
+		// C depends on A only through the decorator of B, so C's hook
+		// must wait for A's slow hook.
+		app := fxtest.New(t,
+			ConcurrentLifecycle(),
 			Provide(
-				func() type3 { return type3{} },
-				module,
+				func(lc Lifecycle) *A {
+					lc.Append(Hook{OnStart: func(ctx context.Context) error {
+						time.Sleep(50 * time.Millisecond)
+						return record("start A")(ctx)
+					}})
+					return &A{}
+				},
+				func() *B { return &B{} },
+				func(lc Lifecycle, _ *B) *C {
+					lc.Append(Hook{OnStart: record("start C")})
+					return &C{}
+				},
 			),
+			Decorate(func(b *B, _ *A) *B { return b }),
+			Invoke(func(*C) {}),
 		)
-		err := app.Err()
-		require.Error(t, err, "expected start failure")
+		app.RequireStart().RequireStop()
 
-		// Example:
-		// fx.Annotated should be passed to fx.Provide directly, it should not be returned by the constructor: fx.Provide received go.uber.org/fx_test.TestAnnotatedWrongUsage.func2.1() from:
-		// go.uber.org/fx_test.TestAnnotatedWrongUsage.func2
-		//         /.../fx/annotated_test.go:76
-		// testing.tRunner
-		//         /.../go/1.13.3/libexec/src/testing/testing.go:909
-		assert.Contains(t, err.Error(), "fx.Option should be passed to fx.New directly, not to fx.Provide")
-		assert.Contains(t, err.Error(), "fx.Provide received fx.Options(fx.Provide(go.uber.org/fx_test.TestAppStart")
-		assert.Contains(t, err.Error(), "go.uber.org/fx_test.TestAppStart")
-		assert.Contains(t, err.Error(), "/app_test.go")
+		assert.Equal(t, []string{"start A", "start C"}, events)
 	})
 
-	t.Run("HookGoroutineExitsErrorMsg", func(t *testing.T) {
+	t.Run("PrivateProvidersOfOtherModules", func(t *testing.T) {
 		t.Parallel()
 
-		addHook := func(lc Lifecycle) {
-			lc.Append(Hook{
-				OnStart: func(ctx context.Context) error {
-					runtime.Goexit()
-					return nil
-				},
-			})
-		}
+		// B depends on the *A provided privately in module "b", not
+		// on the one in module "a", whose hook waits for B's hook to
+		// start.
+		bStarted := make(chan struct{})
 		app := fxtest.New(t,
-			Invoke(addHook),
+			ConcurrentLifecycle(),
+			Module("a",
+				Provide(
+					Private,
+					func(lc Lifecycle) *A {
+						lc.Append(Hook{OnStart: func(ctx context.Context) error {
+							select {
+							case <-bStarted:
+								return nil
+							case <-ctx.Done():
+								return ctx.Err()
+							}
+						}})
+						return &A{}
+					},
+				),
+				Invoke(func(*A) {}),
+			),
+			Module("b",
+				Provide(
+					Private,
+					func() *A { return &A{} },
+				),
+				Provide(func(lc Lifecycle, _ *A) *B {
+					lc.Append(Hook{OnStart: func(context.Context) error {
+						close(bStarted)
+						return nil
+					}})
+					return &B{}
+				}),
+				Invoke(func(*B) {}),
+			),
 		)
-		err := app.Start(context.Background()).Error()
-		assert.Contains(t, "goroutine exited without returning", err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, app.Start(ctx))
+		app.RequireStop()
 	})
 
-	t.Run("StartTwiceWithHooksErrors", func(t *testing.T) {
+	t.Run("Rollback", func(t *testing.T) {
 		t.Parallel()
 
-		ctx, cancel := context.WithCancel(context.Background())
-		defer cancel()
-
-		app := fxtest.New(t,
-			Invoke(func(lc Lifecycle) {
-				lc.Append(Hook{
-					OnStart: func(ctx context.Context) error { return nil },
-					OnStop:  func(ctx context.Context) error { return nil },
-				})
-			}),
+		var stopped atomic.Bool
+		app, spy := NewSpied(
+			ConcurrentLifecycle(),
+			Provide(
+				func(lc Lifecycle) *A {
+					lc.Append(Hook{
+						OnStart: func(context.Context) error { return nil },
+						OnStop: func(context.Context) error {
+							stopped.Store(true)
+							return nil
+						},
+					})
+					return &A{}
+				},
+				func(lc Lifecycle, _ *A) *B {
+					lc.Append(Hook{OnStart: func(context.Context) error {
+						return errors.New("OnStart fail")
+					}})
+					return &B{}
+				},
+				func(lc Lifecycle, _ *B) *C {
+					lc.Append(Hook{OnStart: func(context.Context) error {
+						assert.Fail(t, "should never be called")
+						return nil
+					}})
+					return &C{}
+				},
+			),
+			Invoke(func(*C) {}),
 		)
-		assert.NoError(t, app.Start(ctx))
-		err := app.Start(ctx)
-		if assert.Error(t, err) {
-			assert.ErrorContains(t, err, "attempted to start lifecycle when in state: started")
-		}
-		app.Stop(ctx)
-		assert.NoError(t, app.Start(ctx))
-		app.Stop(ctx)
-	})
-}
+		err := app.Start(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "OnStart fail")
+		assert.True(t, stopped.Load(), "OnStop of started hook must be called")
 
-func TestAppStop(t *testing.T) {
-	t.Parallel()
+		assert.Len(t, spy.Events().SelectByTypeName("OnStartExecuted"), 2)
+		assert.Len(t, spy.Events().SelectByTypeName("RollingBack"), 1)
+		assert.Len(t, spy.Events().SelectByTypeName("RolledBack"), 1)
+	})
 
-	t.Run("Timeout", func(t *testing.T) {
+	t.Run("InvokeHooksAreBarriers", func(t *testing.T) {
 		t.Parallel()
 
-		mockClock := clock.NewMock()
-
-		block := func(ctx context.Context) error {
-			mockClock.Add(5 * time.Second)
-			return ctx.Err()
-		}
-		// NOTE: for tests that gets cancelled/times out during lifecycle methods, it's possible
-		// for them to run into race with fxevent logs getting written to testing.T with the
-		// remainder of the tests. As a workaround, we provide fxlog.Spy to prevent the lifecycle
-		// goroutine from writing to testing.T.
-		spy := new(fxlog.Spy)
-		app := New(Invoke(func(l Lifecycle) { l.Append(Hook{OnStop: block}) }),
-			WithLogger(func() fxevent.Logger { return spy }),
-			WithClock(mockClock),
+		var (
+			mu     sync.Mutex
+			events []string
 		)
+		record := func(ev string) func(context.Context) error {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, ev)
+				return nil
+			}
+		}
 
-		err := app.Start(context.Background())
-		require.Nil(t, err)
-
-		ctx, cancel := mockClock.WithTimeout(context.Background(), time.Second)
-		defer cancel()
+		app := fxtest.New(t,
+			ConcurrentLifecycle(),
+			Provide(
+				func(lc Lifecycle) *A {
+					lc.Append(Hook{OnStart: record("start A")})
+					return &A{}
+				},
+				func(lc Lifecycle) *B {
+					lc.Append(Hook{OnStart: record("start B")})
+					return &B{}
+				},
+			),
+			Invoke(func(_ *A, lc Lifecycle) {
+				lc.Append(Hook{OnStart: record("start invoke")})
+			}),
+			Invoke(func(*B) {}),
+		)
+		app.RequireStart().RequireStop()
 
-		err = app.Stop(ctx)
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "context deadline exceeded")
+		assert.Equal(t, []string{"start A", "start invoke", "start B"}, events)
 	})
 
-	t.Run("StopError", func(t *testing.T) {
+	t.Run("HooksAppendHooks", func(t *testing.T) {
 		t.Parallel()
 
-		failStop := func(lc Lifecycle) struct{} {
-			lc.Append(Hook{OnStop: func(context.Context) error {
-				return errors.New("OnStop fail")
+		// Both hooks append a hook while the other one is running.
+		// Hooks appended during Start aren't run until the next Start.
+		var (
+			wg       sync.WaitGroup
+			appended atomic.Int32
+		)
+		wg.Add(2)
+		appendHook := func(lc Lifecycle) {
+			lc.Append(Hook{OnStart: func(ctx context.Context) error {
+				wg.Done()
+				wg.Wait()
+				lc.Append(Hook{OnStart: func(context.Context) error {
+					appended.Add(1)
+					return nil
+				}})
+				return nil
 			}})
-			return struct{}{}
 		}
 		app := fxtest.New(t,
-			Provide(failStop),
-			Invoke(func(struct{}) {}),
+			ConcurrentLifecycle(),
+			Provide(
+				func(lc Lifecycle) *A {
+					appendHook(lc)
+					return &A{}
+				},
+				func(lc Lifecycle) *B {
+					appendHook(lc)
+					return &B{}
+				},
+			),
+			Invoke(func(*A, *B) {}),
 		)
-		app.RequireStart()
-		err := app.Stop(context.Background())
+		app.RequireStart().RequireStop()
+		assert.Zero(t, appended.Load())
+	})
+
+	t.Run("ModuleOptionFails", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t, Module("foo", ConcurrentLifecycle()))
+		err := app.Err()
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "OnStop fail")
+		assert.Contains(t, err.Error(), "fx.ConcurrentLifecycle Option should be passed to top-level App")
 	})
 }
 
@@ -1840,6 +2978,32 @@ func TestHookConstructors(t *testing.T) {
 		})
 		require.Equal(t, 4, calls)
 	})
+
+	t.Run("ready and drain", func(t *testing.T) {
+		var calls []string
+		record := func(name string) func() {
+			return func() { calls = append(calls, name) }
+		}
+
+		app, spy := NewSpied(Invoke(func(lc Lifecycle) {
+			lc.Append(StartStopHook(record("start"), record("stop")))
+			lc.Append(ReadyHook(record("ready 1")))
+			lc.Append(DrainHook(record("drain 1")))
+			lc.Append(ReadyDrainHook(record("ready 2"), record("drain 2")))
+		}))
+		require.NoError(t, app.Start(context.Background()))
+		require.NoError(t, app.Stop(context.Background()))
+
+		assert.Equal(t, []string{
+			"start", "ready 1", "ready 2",
+			"drain 2", "drain 1", "stop",
+		}, calls)
+
+		ready := spy.Events().SelectByTypeName("OnReadyExecuted")
+		require.Len(t, ready, 2)
+		assert.Contains(t, ready[0].(*fxevent.OnReadyExecuted).FunctionName, "TestHookConstructors")
+		assert.Len(t, spy.Events().SelectByTypeName("OnDrainExecuted"), 2)
+	})
 }
 
 func TestDone(t *testing.T) {
@@ -1861,11 +3025,16 @@ func TestReplaceLogger(t *testing.T) {
 	app := fxtest.New(t, WithLogger(func() fxevent.Logger { return spy }))
 	app.RequireStart().RequireStop()
 	assert.Equal(t, []string{
+		"Provided",
+		"Provided",
+		"Provided",
 		"Provided",
 		"Provided",
 		"Provided",
 		"LoggerInitialized",
+		"StateChanged", "StateChanged",
 		"Started",
+		"StateChanged", "StateChanged",
 		"Stopped",
 	}, spy.EventTypes())
 }
@@ -1899,6 +3068,105 @@ func TestCustomLoggerWithPrinter(t *testing.T) {
 	assert.Contains(t, out, "great sadness")
 }
 
+func TestRunEvents(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+
+	runEvents := func(spy *fxlog.Spy) []*fxevent.Run {
+		var events []*fxevent.Run
+		for _, e := range spy.Events().SelectByTypeName("Run") {
+			events = append(events, e.(*fxevent.Run))
+		}
+		return events
+	}
+
+	t.Run("kinds", func(t *testing.T) {
+		t.Parallel()
+
+		mockClock := clock.NewMock()
+		app, spy := NewSpied(
+			WithClock(mockClock),
+			Provide(func(*B) *A {
+				mockClock.Add(time.Second)
+				return &A{}
+			}),
+			Module("child",
+				Supply(&B{}),
+				Decorate(func(a *A) *A { return a }),
+				Replace(&B{}),
+				Invoke(func(*A) {}),
+				Invoke(func(*B) {}),
+			),
+		)
+		require.NoError(t, app.Err())
+
+		events := runEvents(spy)
+		require.Len(t, events, 4)
+
+		assert.Equal(t, "stub(*fx_test.B)", events[0].Name)
+		assert.Equal(t, "supply", events[0].Kind)
+		assert.Equal(t, "child", events[0].ModuleName)
+
+		assert.Contains(t, events[1].Name, "TestRunEvents")
+		assert.Equal(t, "provide", events[1].Kind)
+		assert.Empty(t, events[1].ModuleName)
+		assert.Equal(t, time.Second, events[1].Runtime)
+
+		assert.Contains(t, events[2].Name, "TestRunEvents")
+		assert.Equal(t, "decorate", events[2].Kind)
+		assert.Equal(t, "child", events[2].ModuleName)
+
+		assert.Equal(t, "stub(*fx_test.B)", events[3].Name)
+		assert.Equal(t, "replace", events[3].Kind)
+		assert.Equal(t, "child", events[3].ModuleName)
+
+		for _, e := range events {
+			assert.NoError(t, e.Err)
+		}
+	})
+
+	t.Run("error", func(t *testing.T) {
+		t.Parallel()
+
+		errSad := errors.New("great sadness")
+		app, spy := NewSpied(
+			Provide(func() (*A, error) { return nil, errSad }),
+			Invoke(func(*A) {}),
+		)
+		require.ErrorIs(t, app.Err(), errSad)
+
+		events := runEvents(spy)
+		require.Len(t, events, 1)
+		assert.Equal(t, "provide", events[0].Kind)
+		assert.ErrorIs(t, events[0].Err, errSad)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(
+			RecoverFromPanics(),
+			Provide(func() *A { panic("great sadness") }),
+			Invoke(func(*A) {}),
+		)
+		require.Error(t, app.Err())
+
+		events := runEvents(spy)
+		require.Len(t, events, 1)
+		assert.ErrorContains(t, events[0].Err, "panic: \"great sadness\"")
+	})
+
+	t.Run("not run", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(Provide(func() *A { return &A{} }))
+		require.NoError(t, app.Err())
+		assert.Empty(t, runEvents(spy))
+	})
+}
+
 func TestCustomLoggerWithLifecycle(t *testing.T) {
 	t.Parallel()
 
@@ -1942,10 +3210,18 @@ func TestCustomLoggerWithLifecycle(t *testing.T) {
 		"Provided",
 		"Provided",
 		"Provided",
+		"Provided",
+		"Provided",
+		"Provided",
+		"Run",
 		"LoggerInitialized",
+		"StateChanged",
 		"OnStartExecuting", "OnStartExecuted",
+		"StateChanged",
 		"Started",
+		"StateChanged",
 		"OnStopExecuting", "OnStopExecuted",
+		"StateChanged",
 		"Stopped",
 	}, spy.EventTypes())
 }
@@ -2143,6 +3419,66 @@ func TestOptionString(t *testing.T) {
 			give: RecoverFromPanics(),
 			want: "fx.RecoverFromPanics()",
 		},
+		{
+			desc: "ConcurrentLifecycle",
+			give: ConcurrentLifecycle(),
+			want: "fx.ConcurrentLifecycle()",
+		},
+		{
+			desc: "DumpGoroutinesOnTimeout",
+			give: DumpGoroutinesOnTimeout(),
+			want: "fx.DumpGoroutinesOnTimeout()",
+		},
+		{
+			desc: "StopPolicy",
+			give: StopPolicy(ContinueAfterDeadline),
+			want: "fx.StopPolicy(fx.ContinueAfterDeadline)",
+		},
+		{
+			desc: "ShutdownSignals",
+			give: ShutdownSignals(syscall.SIGTERM, syscall.SIGQUIT),
+			want: "fx.ShutdownSignals(terminated, quit)",
+		},
+		{
+			desc: "NoSignalHandling",
+			give: NoSignalHandling(),
+			want: "fx.NoSignalHandling()",
+		},
+		{
+			desc: "ForceExitOnSecondSignal",
+			give: ForceExitOnSecondSignal(),
+			want: "fx.ForceExitOnSecondSignal()",
+		},
+		{
+			desc: "SystemdNotify",
+			give: SystemdNotify(),
+			want: "fx.SystemdNotify()",
+		},
+		{
+			desc: "PanicExitCode",
+			give: PanicExitCode(3),
+			want: "fx.PanicExitCode(3)",
+		},
+		{
+			desc: "ReportUnused",
+			give: ReportUnused(),
+			want: "fx.ReportUnused()",
+		},
+		{
+			desc: "TraceStartup",
+			give: TraceStartup(&bytes.Buffer{}),
+			want: "fx.TraceStartup(*bytes.Buffer)",
+		},
+		{
+			desc: "ExitCodeMapper",
+			give: ExitCodeMapper(errorExitCode),
+			want: "fx.ExitCodeMapper(go.uber.org/fx_test.errorExitCode())",
+		},
+		{
+			desc: "SlowHookThreshold",
+			give: SlowHookThreshold(time.Second),
+			want: "fx.SlowHookThreshold(1s)",
+		},
 		{
 			desc: "Logger",
 			give: WithLogger(func() fxevent.Logger { return testLogger{t} }),
@@ -2193,6 +3529,9 @@ func TestOptionString(t *testing.T) {
 }
 
 func TestMain(m *testing.M) {
+	if mode := os.Getenv(_upgradeChildEnv); mode != "" {
+		os.Exit(runUpgradeChild(mode))
+	}
 	goleak.VerifyTestMain(m)
 }
 
@@ -2210,6 +3549,8 @@ func (l testLogger) String() string {
 	return l.t.Name()
 }
 
+func errorExitCode(error) int { return 2 }
+
 type testErrorHandler struct{ t *testing.T }
 
 func (h testErrorHandler) HandleError(err error) {
@@ -23,7 +23,13 @@
 
 package fx
 
-import "golang.org/x/sys/unix"
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
 
 const _sigINT = unix.SIGINT
 const _sigTERM = unix.SIGTERM
+
+var _reloadSignals = []os.Signal{unix.SIGHUP}
@@ -23,7 +23,14 @@
 
 package fx
 
-import "syscall"
+import (
+	"os"
+	"syscall"
+)
 
 const _sigINT = syscall.SIGINT
 const _sigTERM = syscall.SIGTERM
+
+// There's no SIGHUP on js/wasm, so applications can only be reloaded with
+// App.Reload.
+var _reloadSignals []os.Signal
@@ -23,7 +23,13 @@
 
 package fx
 
-import "golang.org/x/sys/windows"
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
 
 const _sigINT = windows.SIGINT
 const _sigTERM = windows.SIGTERM
+
+var _reloadSignals = []os.Signal{windows.SIGHUP}
@@ -0,0 +1,272 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/dig"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxreflect"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// constructorNode records a constructor that was provided to the container,
+// along with the keys of the values it consumes and produces.
+//
+// It serves as the owner of lifecycle hooks appended while the constructor
+// runs, so that concurrent lifecycles can order those hooks relative to the
+// hooks of the constructor's dependencies, and so that hooks can be
+// attributed to the module that provided the constructor.
+type constructorNode struct {
+	app     *App
+	module  *module
+	provide provide
+	info    dig.ProvideInfo
+	inputs  []string
+	outputs []string
+
+	// Whether the container has called the constructor.
+	invoked bool
+
+	// Transitive dependencies of this constructor. Built lazily because
+	// the constructors a node depends on may be provided after it.
+	deps map[*constructorNode]struct{}
+}
+
+var _ lifecycle.HookOwner = (*constructorNode)(nil)
+
+// register records the inputs and outputs of a successfully provided
+// constructor and indexes it by its outputs.
+func (n *constructorNode) register(info dig.ProvideInfo) {
+	n.info = info
+	n.app.constructors = append(n.app.constructors, n)
+
+	n.inputs = inputKeys(info.Inputs)
+
+	n.outputs = make([]string, len(info.Outputs))
+	for i, out := range info.Outputs {
+		n.outputs[i] = out.String()
+		n.app.providers[n.outputs[i]] = append(n.app.providers[n.outputs[i]], n)
+	}
+}
+
+// inputKey returns a string identifying the value consumed by the given
+// input. It matches the String() of the corresponding dig.Output.
+func inputKey(in *dig.Input) string {
+	s := in.String()
+	s = strings.Replace(s, "[optional]", "", 1)
+	s = strings.Replace(s, "[optional, ", "[", 1)
+	if strings.Contains(s, "[group = ") {
+		// Value groups are consumed as slices of the produced type.
+		s = strings.TrimPrefix(s, "[]")
+	}
+	return s
+}
+
+// decoratedKeys returns strings identifying the values produced by a
+// decorator. They match the keys returned by inputKey.
+func decoratedKeys(outs []*dig.Output) []string {
+	keys := make([]string, len(outs))
+	for i, out := range outs {
+		keys[i] = out.String()
+		if strings.Contains(keys[i], "[group = ") {
+			// Decorators of value groups produce slices of the
+			// grouped type.
+			keys[i] = strings.TrimPrefix(keys[i], "[]")
+		}
+	}
+	return keys
+}
+
+// DependsOn reports whether this constructor consumes, directly or
+// transitively, a value produced by the given owner.
+func (n *constructorNode) DependsOn(o lifecycle.HookOwner) bool {
+	dep, ok := o.(*constructorNode)
+	if !ok {
+		return false
+	}
+	if n.deps == nil {
+		n.deps = make(map[*constructorNode]struct{})
+		n.collectDeps(n.deps)
+	}
+	_, ok = n.deps[dep]
+	return ok
+}
+
+func (n *constructorNode) collectDeps(deps map[*constructorNode]struct{}) {
+	for _, in := range n.inputs {
+		n.app.resolve(n.module, in, nil, func(p *constructorNode, _ string) {
+			if _, ok := deps[p]; ok {
+				return
+			}
+			deps[p] = struct{}{}
+			p.collectDeps(deps)
+		})
+	}
+}
+
+// scope returns the module whose scope the constructor was provided to.
+// Constructors not marked private are provided to the root.
+func (n *constructorNode) scope() *module {
+	if n.provide.Private {
+		return n.module
+	}
+	return n.app.root
+}
+
+// decoratorNode records a decorator that was applied to the container.
+type decoratorNode struct {
+	module    *module
+	decorator decorator
+	info      dig.DecorateInfo
+	inputs    []string
+	outputs   []string
+}
+
+// visibleProviders returns the constructors that the container calls to
+// produce the value identified by key for a function in module m.
+//
+// Like the container, it looks for the value in m and then in each of its
+// enclosing modules, stopping at the first one that provides it. Value
+// groups are fed by the constructors in all of these modules.
+func (app *App) visibleProviders(m *module, key string) []*constructorNode {
+	group := strings.Contains(key, "[group = ")
+	var found []*constructorNode
+	for s := m; s != nil; s = s.parent {
+		for _, p := range app.providers[key] {
+			if p.scope() == s {
+				found = append(found, p)
+			}
+		}
+		if len(found) > 0 && !group {
+			break
+		}
+	}
+	return found
+}
+
+// visibleDecorator returns the decorator that the container runs to produce
+// the value identified by key for a function in module m, or nil if the
+// value isn't decorated there. Decorators that are already running are skipped,
+// as the container does for decorators that consume the value they
+// decorate.
+func (app *App) visibleDecorator(m *module, key string, running []*decoratorNode) *decoratorNode {
+	for s := m; s != nil; s = s.parent {
+	decorators:
+		for _, d := range app.decorators {
+			if d.module != s {
+				continue
+			}
+			for _, r := range running {
+				if r == d {
+					continue decorators
+				}
+			}
+			for _, out := range d.outputs {
+				if out == key {
+					return d
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// resolve calls fn with each constructor that the container calls to
+// produce the value identified by key for a function in module m, along with
+// the key of the value that the constructor produces. If the value is
+// decorated, these are the constructors of the values the decorators
+// consume.
+func (app *App) resolve(m *module, key string, running []*decoratorNode, fn func(*constructorNode, string)) {
+	if d := app.visibleDecorator(m, key, running); d != nil {
+		running = append(running[:len(running):len(running)], d)
+		for _, in := range d.inputs {
+			app.resolve(d.module, in, running, fn)
+		}
+		return
+	}
+	for _, p := range app.visibleProviders(m, key) {
+		fn(p, key)
+	}
+}
+
+// runReporter reports the runs of a constructor or decorator by the
+// container with fxevent.Run events and startup trace spans. Its methods are
+// passed to the container as callbacks.
+type runReporter struct {
+	module     *module
+	name, kind string
+
+	// Constructor marked as running while the container calls it. Nil for
+	// decorators.
+	node *constructorNode
+
+	start time.Time
+	prev  *constructorNode
+	end   func(error)
+}
+
+func (r *runReporter) before(dig.BeforeCallbackInfo) {
+	app := r.module.app
+	if r.node != nil {
+		r.node.invoked = true
+		r.prev = app.runningCtor
+		app.runningCtor = r.node
+	}
+	r.start = app.clock.Now()
+	r.end = app.tracer.start("run,"+r.kind, r.name, r.module.name)
+}
+
+func (r *runReporter) after(ci dig.CallbackInfo) {
+	app := r.module.app
+	if r.node != nil {
+		app.runningCtor = r.prev
+	}
+	r.end(ci.Error)
+	r.module.log.LogEvent(&fxevent.Run{
+		Name:       r.name,
+		Kind:       r.kind,
+		ModuleName: r.module.name,
+		Runtime:    app.clock.Since(r.start),
+		Err:        ci.Error,
+	})
+}
+
+// describe returns the name of the constructor and the option it was
+// provided with, as reported by fxevent.Run.
+func (p provide) describe() (name, kind string) {
+	if p.IsSupply {
+		return fmt.Sprintf("stub(%v)", p.SupplyType), "supply"
+	}
+	return fxreflect.FuncName(p.Target), "provide"
+}
+
+// describe returns the name of the decorator and the option it was provided
+// with.
+func (d decorator) describe() (name, kind string) {
+	if d.IsReplace {
+		return fmt.Sprintf("stub(%v)", d.ReplaceType), "replace"
+	}
+	return fxreflect.FuncName(d.Target), "decorate"
+}
@@ -466,6 +466,9 @@ func TestDecorateFailure(t *testing.T) {
 		err := app.Err()
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "missing dependencies")
+		assert.Contains(t, err.Error(),
+			`missing dependencies for function "go.uber.org/fx_test".TestDecorateFailure`,
+			"error should name the decorator")
 	})
 
 	t.Run("decorate cannot provide a non-existent type", func(t *testing.T) {
@@ -0,0 +1,114 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"errors"
+	"fmt"
+
+	"go.uber.org/fx/internal/fxreflect"
+)
+
+// WithExitCode wraps err so that App.Run exits with the given code if err
+// makes the application fail to start or stop. Constructors, invoked
+// functions, and lifecycle hooks may return such errors to tell the process
+// supervisor why the application failed.
+//
+//	cfg, err := loadConfig()
+//	if err != nil {
+//		return nil, fx.WithExitCode(err, 78) // EX_CONFIG
+//	}
+//
+// The error may be wrapped further; Run finds it with errors.As. WithExitCode
+// returns nil if err is nil.
+func WithExitCode(err error, code int) error {
+	if err == nil {
+		return nil
+	}
+	return &exitCodeError{err: err, code: code}
+}
+
+type exitCodeError struct {
+	err  error
+	code int
+}
+
+func (e *exitCodeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitCodeError) Unwrap() error {
+	return e.err
+}
+
+// ExitCode returns the exit code for the error. App.RunContext documents
+// how to retrieve it.
+func (e *exitCodeError) ExitCode() int {
+	return e.code
+}
+
+// ExitCodeMapper specifies how App.Run turns the error that made the
+// application fail to start or stop, including errors from New, into a
+// process exit code.
+//
+// If the mapper returns 0, or if no mapper is given, Run uses the code passed
+// to WithExitCode, or 1 if the error wasn't wrapped with WithExitCode.
+//
+//	fx.ExitCodeMapper(func(err error) int {
+//		if errors.Is(err, context.DeadlineExceeded) {
+//			return 75 // EX_TEMPFAIL
+//		}
+//		return 0
+//	})
+func ExitCodeMapper(mapper func(error) int) Option {
+	return exitCodeMapperOption(mapper)
+}
+
+type exitCodeMapperOption func(error) int
+
+func (o exitCodeMapperOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.ExitCodeMapper Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.exitCodeMapper = o
+	}
+}
+
+func (o exitCodeMapperOption) String() string {
+	return fmt.Sprintf("fx.ExitCodeMapper(%v)", fxreflect.FuncName(o))
+}
+
+// exitCode returns the exit code for an application that failed with the
+// given error.
+func (app *App) exitCode(err error) int {
+	if app.exitCodeMapper != nil {
+		if code := app.exitCodeMapper(err); code != 0 {
+			return code
+		}
+	}
+
+	var codeErr *exitCodeError
+	if errors.As(err, &codeErr) && codeErr.code != 0 {
+		return codeErr.code
+	}
+	return 1
+}
@@ -51,6 +51,26 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		} else {
 			l.logf("HOOK OnStart\t\t%s called by %s ran successfully in %s", e.FunctionName, e.CallerName, e.Runtime)
 		}
+	case *OnStartRetrying:
+		l.logf("HOOK OnStart\t\t%s called by %s failed on attempt %d, retrying in %s: %+v", e.FunctionName, e.CallerName, e.Attempt, e.Backoff, e.Err)
+	case *OnStartSlow:
+		l.logf("HOOK OnStart\t\t%s called by %s is still running after %s", e.FunctionName, e.CallerName, e.Elapsed)
+	case *OnReadyExecuting:
+		l.logf("HOOK OnReady\t\t%s executing (caller: %s)", e.FunctionName, e.CallerName)
+	case *OnReadyExecuted:
+		if e.Err != nil {
+			l.logf("HOOK OnReady\t\t%s called by %s failed in %s: %+v", e.FunctionName, e.CallerName, e.Runtime, e.Err)
+		} else {
+			l.logf("HOOK OnReady\t\t%s called by %s ran successfully in %s", e.FunctionName, e.CallerName, e.Runtime)
+		}
+	case *OnDrainExecuting:
+		l.logf("HOOK OnDrain\t\t%s executing (caller: %s)", e.FunctionName, e.CallerName)
+	case *OnDrainExecuted:
+		if e.Err != nil {
+			l.logf("HOOK OnDrain\t\t%s called by %s failed in %s: %+v", e.FunctionName, e.CallerName, e.Runtime, e.Err)
+		} else {
+			l.logf("HOOK OnDrain\t\t%s called by %s ran successfully in %s", e.FunctionName, e.CallerName, e.Runtime)
+		}
 	case *OnStopExecuting:
 		l.logf("HOOK OnStop\t\t%s executing (caller: %s)", e.FunctionName, e.CallerName)
 	case *OnStopExecuted:
@@ -59,6 +79,10 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		} else {
 			l.logf("HOOK OnStop\t\t%s called by %s ran successfully in %s", e.FunctionName, e.CallerName, e.Runtime)
 		}
+	case *OnStopSlow:
+		l.logf("HOOK OnStop\t\t%s called by %s is still running after %s", e.FunctionName, e.CallerName, e.Elapsed)
+	case *GoroutineDump:
+		l.logf("HOOK %s\t\t%s called by %s timed out, running in:\n%s", e.Method, e.FunctionName, e.CallerName, e.Stack)
 	case *Supplied:
 		if e.Err != nil {
 			l.logf("ERROR\tFailed to supply %v: %+v", e.TypeName, e.Err)
@@ -105,6 +129,15 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		if e.Err != nil {
 			l.logf("Error after options were applied: %+v", e.Err)
 		}
+	case *Run:
+		var moduleStr string
+		if e.ModuleName != "" {
+			moduleStr = fmt.Sprintf(" from module %q", e.ModuleName)
+		}
+		l.logf("RUN\t%v: %v in %s%v", e.Kind, e.Name, e.Runtime, moduleStr)
+		if e.Err != nil {
+			l.logf("Error returned: %+v", e.Err)
+		}
 	case *Invoking:
 		if e.ModuleName != "" {
 			l.logf("INVOKE\t\t%s from module %q", e.FunctionName, e.ModuleName)
@@ -115,12 +148,35 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		if e.Err != nil {
 			l.logf("ERROR\t\tfx.Invoke(%v) called from:\n%+vFailed: %+v", e.FunctionName, e.Trace, e.Err)
 		}
+	case *Unused:
+		var from string
+		if e.ConstructorName != "" {
+			from = " <= " + e.ConstructorName
+		}
+		for _, rtype := range e.OutputTypeNames {
+			if e.ModuleName != "" {
+				l.logf("UNUSED\t%v%v from module %q, provided at %v", rtype, from, e.ModuleName, e.Location)
+			} else {
+				l.logf("UNUSED\t%v%v, provided at %v", rtype, from, e.Location)
+			}
+		}
 	case *Stopping:
-		l.logf("%v", strings.ToUpper(e.Signal.String()))
+		if e.Cause != nil {
+			l.logf("%v: %+v", strings.ToUpper(e.Signal.String()), e.Cause)
+		} else {
+			l.logf("%v", strings.ToUpper(e.Signal.String()))
+		}
 	case *Stopped:
 		if e.Err != nil {
 			l.logf("ERROR\t\tFailed to stop cleanly: %+v", e.Err)
 		}
+	case *ForceExiting:
+		if e.CallerName != "" {
+			l.logf("ERROR\t\t%v received while stopping, exiting during hook appended by %s",
+				strings.ToUpper(e.Signal.String()), e.CallerName)
+		} else {
+			l.logf("ERROR\t\t%v received while stopping, exiting", strings.ToUpper(e.Signal.String()))
+		}
 	case *RollingBack:
 		l.logf("ERROR\t\tStart failed, rolling back: %+v", e.StartErr)
 	case *RolledBack:
@@ -133,6 +189,33 @@ func (l *ConsoleLogger) LogEvent(event Event) {
 		} else {
 			l.logf("RUNNING")
 		}
+	case *StartSkipped:
+		l.logf("SKIPPED\t\tShutdown requested before start (%v)", strings.ToUpper(e.Signal.String()))
+	case *BackgroundPanic:
+		l.logf("ERROR\t\tGoroutine %s panicked: %v\n%s", e.FunctionName, e.Recovered, e.Stack)
+	case *Restarting:
+		l.logf("RESTARTING")
+	case *Restarted:
+		if e.Err != nil {
+			l.logf("ERROR\t\tFailed to restart: %+v", e.Err)
+		} else {
+			l.logf("RUNNING")
+		}
+	case *Reloading:
+		if e.Signal != nil {
+			l.logf("RELOADING\t%v", strings.ToUpper(e.Signal.String()))
+		} else {
+			l.logf("RELOADING")
+		}
+	case *Reloaded:
+		if e.Err != nil {
+			l.logf("ERROR\t\tFailed to reload: %+v", e.Err)
+		} else {
+			l.logf("RELOADED")
+		}
+	case *StateChanged:
+		// State changes are evident from the other events logged to the
+		// console.
 	case *LoggerInitialized:
 		if e.Err != nil {
 			l.logf("ERROR\t\tFailed to initialize custom logger: %+v", e.Err)
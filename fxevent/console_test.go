@@ -116,6 +116,98 @@ func TestConsoleLogger(t *testing.T) {
 			},
 			want: "[Fx] HOOK OnStart		hook.onStart1 called by bytes.NewBuffer failed in 0s: rich error\n",
 		},
+		{
+			name: "OnReadyExecuting",
+			give: &OnReadyExecuting{
+				FunctionName: "hook.onReady",
+				CallerName:   "bytes.NewBuffer",
+			},
+			want: "[Fx] HOOK OnReady		hook.onReady executing (caller: bytes.NewBuffer)\n",
+		},
+		{
+			name: "OnReadyExecutedError",
+			give: &OnReadyExecuted{
+				FunctionName: "hook.onReady",
+				CallerName:   "bytes.NewBuffer",
+				Err:          fmt.Errorf("some error"),
+			},
+			want: "[Fx] HOOK OnReady		hook.onReady called by bytes.NewBuffer failed in 0s: some error\n",
+		},
+		{
+			name: "OnReadyExecuted",
+			give: &OnReadyExecuted{
+				FunctionName: "hook.onReady",
+				CallerName:   "bytes.NewBuffer",
+				Runtime:      time.Millisecond * 3,
+			},
+			want: "[Fx] HOOK OnReady		hook.onReady called by bytes.NewBuffer ran successfully in 3ms\n",
+		},
+		{
+			name: "OnDrainExecuting",
+			give: &OnDrainExecuting{
+				FunctionName: "hook.onDrain",
+				CallerName:   "bytes.NewBuffer",
+			},
+			want: "[Fx] HOOK OnDrain		hook.onDrain executing (caller: bytes.NewBuffer)\n",
+		},
+		{
+			name: "OnDrainExecutedError",
+			give: &OnDrainExecuted{
+				FunctionName: "hook.onDrain",
+				CallerName:   "bytes.NewBuffer",
+				Err:          fmt.Errorf("some error"),
+			},
+			want: "[Fx] HOOK OnDrain		hook.onDrain called by bytes.NewBuffer failed in 0s: some error\n",
+		},
+		{
+			name: "OnDrainExecuted",
+			give: &OnDrainExecuted{
+				FunctionName: "hook.onDrain",
+				CallerName:   "bytes.NewBuffer",
+				Runtime:      time.Millisecond * 3,
+			},
+			want: "[Fx] HOOK OnDrain		hook.onDrain called by bytes.NewBuffer ran successfully in 3ms\n",
+		},
+		{
+			name: "OnStartSlow",
+			give: &OnStartSlow{
+				FunctionName: "hook.onStart",
+				CallerName:   "bytes.NewBuffer",
+				Elapsed:      time.Second * 5,
+			},
+			want: "[Fx] HOOK OnStart		hook.onStart called by bytes.NewBuffer is still running after 5s\n",
+		},
+		{
+			name: "OnStopSlow",
+			give: &OnStopSlow{
+				FunctionName: "hook.onStop",
+				CallerName:   "bytes.NewBuffer",
+				Elapsed:      time.Second * 5,
+			},
+			want: "[Fx] HOOK OnStop		hook.onStop called by bytes.NewBuffer is still running after 5s\n",
+		},
+		{
+			name: "GoroutineDump",
+			give: &GoroutineDump{
+				Method:       "OnStart",
+				FunctionName: "hook.onStart",
+				CallerName:   "bytes.NewBuffer",
+				Stack:        "goroutine 1 [select]:\nmain.main()",
+			},
+			want: "[Fx] HOOK OnStart		hook.onStart called by bytes.NewBuffer timed out, running in:\n" +
+				"goroutine 1 [select]:\nmain.main()\n",
+		},
+		{
+			name: "OnStartRetrying",
+			give: &OnStartRetrying{
+				FunctionName: "hook.onStart",
+				CallerName:   "bytes.NewBuffer",
+				Attempt:      2,
+				Backoff:      time.Millisecond * 200,
+				Err:          fmt.Errorf("some error"),
+			},
+			want: "[Fx] HOOK OnStart		hook.onStart called by bytes.NewBuffer failed on attempt 2, retrying in 200ms: some error\n",
+		},
 		{
 			name: "OnStartExecuted",
 			give: &OnStartExecuted{
@@ -245,6 +337,38 @@ func TestConsoleLogger(t *testing.T) {
 			give: &Invoking{FunctionName: "bytes.NewBuffer()"},
 			want: "[Fx] INVOKE		bytes.NewBuffer()\n",
 		},
+		{
+			name: "Run",
+			give: &Run{
+				Name:    "bytes.NewBuffer()",
+				Kind:    "provide",
+				Runtime: 3 * time.Millisecond,
+			},
+			want: "[Fx] RUN	provide: bytes.NewBuffer() in 3ms\n",
+		},
+		{
+			name: "Run with module",
+			give: &Run{
+				Name:       "stub(*bytes.Buffer)",
+				Kind:       "supply",
+				ModuleName: "myModule",
+				Runtime:    time.Microsecond,
+			},
+			want: "[Fx] RUN	supply: stub(*bytes.Buffer) in 1µs from module \"myModule\"\n",
+		},
+		{
+			name: "Run/Error",
+			give: &Run{
+				Name:    "bytes.NewBuffer()",
+				Kind:    "decorate",
+				Runtime: time.Millisecond,
+				Err:     errors.New("some error"),
+			},
+			want: joinLines(
+				"[Fx] RUN	decorate: bytes.NewBuffer() in 1ms",
+				"[Fx] Error returned: some error",
+			),
+		},
 		{
 			name: "Invoking with module",
 			give: &Invoking{
@@ -281,6 +405,24 @@ func TestConsoleLogger(t *testing.T) {
 				"Failed: rich error",
 			),
 		},
+		{
+			name: "Unused",
+			give: &Unused{
+				ConstructorName: "bytes.NewBuffer()",
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				Location:        "main.main (main.go:42)",
+			},
+			want: "[Fx] UNUSED	*bytes.Buffer <= bytes.NewBuffer(), provided at main.main (main.go:42)\n",
+		},
+		{
+			name: "Unused with module",
+			give: &Unused{
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				ModuleName:      "myModule",
+				Location:        "main.main (main.go:42)",
+			},
+			want: "[Fx] UNUSED	*bytes.Buffer from module \"myModule\", provided at main.main (main.go:42)\n",
+		},
 		{
 			name: "StartError",
 			give: &Started{Err: errors.New("some error")},
@@ -296,6 +438,11 @@ func TestConsoleLogger(t *testing.T) {
 			give: &Stopping{Signal: os.Interrupt},
 			want: "[Fx] INTERRUPT\n",
 		},
+		{
+			name: "StoppingWithCause",
+			give: &Stopping{Signal: os.Interrupt, Cause: errors.New("some error")},
+			want: "[Fx] INTERRUPT: some error\n",
+		},
 		{
 			name: "Stopped",
 			give: &Stopped{Err: errors.New("some error")},
@@ -306,6 +453,16 @@ func TestConsoleLogger(t *testing.T) {
 			give: &Stopped{Err: &richError{}},
 			want: "[Fx] ERROR		Failed to stop cleanly: rich error\n",
 		},
+		{
+			name: "ForceExiting",
+			give: &ForceExiting{Signal: os.Interrupt, CallerName: "bytes.NewBuffer"},
+			want: "[Fx] ERROR		INTERRUPT received while stopping, exiting during hook appended by bytes.NewBuffer\n",
+		},
+		{
+			name: "ForceExiting/NoHook",
+			give: &ForceExiting{Signal: os.Interrupt},
+			want: "[Fx] ERROR		INTERRUPT received while stopping, exiting\n",
+		},
 		{
 			name: "RollingBack",
 			give: &RollingBack{StartErr: errors.New("some error")},
@@ -331,6 +488,56 @@ func TestConsoleLogger(t *testing.T) {
 			give: &Started{},
 			want: "[Fx] RUNNING\n",
 		},
+		{
+			name: "StartSkipped",
+			give: &StartSkipped{Signal: os.Interrupt},
+			want: "[Fx] SKIPPED		Shutdown requested before start (INTERRUPT)\n",
+		},
+		{
+			name: "BackgroundPanic",
+			give: &BackgroundPanic{FunctionName: "bytes.NewBuffer", Recovered: "great sadness", Stack: "stack"},
+			want: "[Fx] ERROR		Goroutine bytes.NewBuffer panicked: great sadness\nstack\n",
+		},
+		{
+			name: "Restarting",
+			give: &Restarting{},
+			want: "[Fx] RESTARTING\n",
+		},
+		{
+			name: "Restarted",
+			give: &Restarted{},
+			want: "[Fx] RUNNING\n",
+		},
+		{
+			name: "RestartedError",
+			give: &Restarted{Err: errors.New("some error")},
+			want: "[Fx] ERROR		Failed to restart: some error\n",
+		},
+		{
+			name: "Reloading",
+			give: &Reloading{},
+			want: "[Fx] RELOADING\n",
+		},
+		{
+			name: "ReloadingSignal",
+			give: &Reloading{Signal: os.Interrupt},
+			want: "[Fx] RELOADING\tINTERRUPT\n",
+		},
+		{
+			name: "Reloaded",
+			give: &Reloaded{},
+			want: "[Fx] RELOADED\n",
+		},
+		{
+			name: "ReloadedError",
+			give: &Reloaded{Err: errors.New("some error")},
+			want: "[Fx] ERROR\t\tFailed to reload: some error\n",
+		},
+		{
+			name: "StateChanged",
+			give: &StateChanged{From: "stopped", To: "starting"},
+			want: "",
+		},
 		{
 			name: "CustomLoggerError",
 			give: &LoggerInitialized{Err: errors.New("great sadness")},
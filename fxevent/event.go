@@ -33,19 +33,37 @@ type Event interface {
 // Passing events by type to make Event hashable in the future.
 func (*OnStartExecuting) event()  {}
 func (*OnStartExecuted) event()   {}
+func (*OnStartRetrying) event()   {}
+func (*OnStartSlow) event()       {}
+func (*OnReadyExecuting) event()  {}
+func (*OnReadyExecuted) event()   {}
+func (*OnDrainExecuting) event()  {}
+func (*OnDrainExecuted) event()   {}
 func (*OnStopExecuting) event()   {}
 func (*OnStopExecuted) event()    {}
+func (*OnStopSlow) event()        {}
+func (*GoroutineDump) event()     {}
 func (*Supplied) event()          {}
 func (*Provided) event()          {}
 func (*Replaced) event()          {}
 func (*Decorated) event()         {}
 func (*Invoking) event()          {}
 func (*Invoked) event()           {}
+func (*Unused) event()            {}
+func (*Run) event()               {}
 func (*Stopping) event()          {}
 func (*Stopped) event()           {}
+func (*ForceExiting) event()      {}
 func (*RollingBack) event()       {}
 func (*RolledBack) event()        {}
 func (*Started) event()           {}
+func (*StartSkipped) event()      {}
+func (*BackgroundPanic) event()   {}
+func (*Restarting) event()        {}
+func (*Restarted) event()         {}
+func (*Reloading) event()         {}
+func (*Reloaded) event()          {}
+func (*StateChanged) event()      {}
 func (*LoggerInitialized) event() {}
 
 // OnStartExecuting is emitted before an OnStart hook is exeucted.
@@ -74,6 +92,101 @@ type OnStartExecuted struct {
 	// Runtime specifies how long it took to run this hook.
 	Runtime time.Duration
 
+	// Timeout is the timeout that was specified for this hook, if any.
+	Timeout time.Duration
+
+	// Err is non-nil if the hook failed to execute.
+	Err error
+}
+
+// OnStartRetrying is emitted when an OnStart hook failed and will be
+// retried after a backoff.
+type OnStartRetrying struct {
+	// FunctionName is the name of the function that failed.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Attempt is the number of attempts made so far, starting at 1.
+	Attempt int
+
+	// Backoff is how long Fx will wait before the next attempt.
+	Backoff time.Duration
+
+	// Err is the error returned by the failed attempt.
+	Err error
+}
+
+// OnStartSlow is emitted periodically while an OnStart hook runs for longer
+// than the threshold specified with fx.SlowHookThreshold.
+type OnStartSlow struct {
+	// FunctionName is the name of the function that is running.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Elapsed is how long the hook has been running so far.
+	Elapsed time.Duration
+}
+
+// OnReadyExecuting is emitted before an OnReady hook is executed.
+type OnReadyExecuting struct {
+	// FunctionName is the name of the function that will be executed.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+}
+
+// OnReadyExecuted is emitted after an OnReady hook has been executed.
+type OnReadyExecuted struct {
+	// FunctionName is the name of the function that was executed.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Runtime specifies how long it took to run this hook.
+	Runtime time.Duration
+
+	// Timeout is the timeout that was specified for this hook, if any.
+	Timeout time.Duration
+
+	// Err is non-nil if the hook failed to execute.
+	Err error
+}
+
+// OnDrainExecuting is emitted before an OnDrain hook is executed.
+type OnDrainExecuting struct {
+	// FunctionName is the name of the function that will be executed.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+}
+
+// OnDrainExecuted is emitted after an OnDrain hook has been executed.
+type OnDrainExecuted struct {
+	// FunctionName is the name of the function that was executed.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Runtime specifies how long it took to run this hook.
+	Runtime time.Duration
+
+	// Timeout is the timeout that was specified for this hook, if any.
+	Timeout time.Duration
+
 	// Err is non-nil if the hook failed to execute.
 	Err error
 }
@@ -100,10 +213,44 @@ type OnStopExecuted struct {
 	// Runtime specifies how long it took to run this hook.
 	Runtime time.Duration
 
+	// Timeout is the timeout that was specified for this hook, if any.
+	Timeout time.Duration
+
 	// Err is non-nil if the hook failed to execute.
 	Err error
 }
 
+// OnStopSlow is emitted periodically while an OnStop hook runs for longer
+// than the threshold specified with fx.SlowHookThreshold.
+type OnStopSlow struct {
+	// FunctionName is the name of the function that is running.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Elapsed is how long the hook has been running so far.
+	Elapsed time.Duration
+}
+
+// GoroutineDump is emitted when a hook times out and fx.DumpGoroutinesOnTimeout
+// is used. It holds the stack trace of the goroutine running the hook.
+type GoroutineDump struct {
+	// Method is the kind of hook that timed out, e.g. "OnStart".
+	Method string
+
+	// FunctionName is the name of the hook function that timed out.
+	FunctionName string
+
+	// CallerName is the name of the function that scheduled the hook for
+	// execution.
+	CallerName string
+
+	// Stack is the stack trace of the goroutine running the hook.
+	Stack string
+}
+
 // Supplied is emitted after a value is added with fx.Supply.
 type Supplied struct {
 	// TypeName is the name of the type of value that was added.
@@ -166,6 +313,26 @@ type Decorated struct {
 	Err error
 }
 
+// Run is emitted after a constructor, decorator, or supply/replace stub is
+// run by Fx.
+type Run struct {
+	// Name is the name of the function that was run.
+	Name string
+
+	// Kind indicates which Fx option was used to pass along the function.
+	// It is either "provide", "decorate", "supply", or "replace".
+	Kind string
+
+	// ModuleName is the name of the module in which the function belongs.
+	ModuleName string
+
+	// Runtime specifies how long it took to run this function.
+	Runtime time.Duration
+
+	// Err is non-nil if the function returned an error or panicked.
+	Err error
+}
+
 // Invoking is emitted before we invoke a function specified with fx.Invoke.
 type Invoking struct {
 	// FunctionName is the name of the function that will be invoked.
@@ -192,6 +359,28 @@ type Invoked struct {
 	Trace string
 }
 
+// Unused is emitted for each constructor that produces values that nothing
+// reachable from an fx.Invoke consumes, if the fx.ReportUnused option is
+// used. These events are emitted after the application's invokes run,
+// grouped by module.
+type Unused struct {
+	// ConstructorName is the name of the constructor that produces the
+	// unused values. It is empty for values provided with fx.Supply.
+	ConstructorName string
+
+	// OutputTypeNames is a list of names of the unused types produced by
+	// this constructor.
+	OutputTypeNames []string
+
+	// ModuleName is the name of the module in which the constructor was
+	// provided to.
+	ModuleName string
+
+	// Location records where the constructor was provided, in the form
+	// "package.Function (path/to/file.go:42)".
+	Location string
+}
+
 // Started is emitted when an application is started successfully and/or it
 // errored.
 type Started struct {
@@ -199,12 +388,38 @@ type Started struct {
 	Err error
 }
 
+// StartSkipped is emitted when the application is started after it was asked
+// to shut down, for example by calling fx.Shutdowner from fx.Invoke. None of
+// its hooks are executed in this case.
+type StartSkipped struct {
+	// Signal is the signal that the application was asked to shut down
+	// with.
+	Signal os.Signal
+}
+
+// BackgroundPanic is emitted when a goroutine started with fx.GoSafe panics.
+// The application shuts down afterwards.
+type BackgroundPanic struct {
+	// FunctionName is the name of the function that panicked.
+	FunctionName string
+
+	// Recovered is the value that the function panicked with.
+	Recovered interface{}
+
+	// Stack is the stack trace of the goroutine that panicked.
+	Stack string
+}
+
 // Stopping is emitted when the application receives a signal to shut down
 // after starting. This may happen with fx.Shutdowner or by sending a signal to
 // the application on the command line.
 type Stopping struct {
 	// Signal is the signal that caused this shutdown.
 	Signal os.Signal
+
+	// Cause is the error passed to fx.Shutdowner with fx.ShutdownCause, if
+	// any.
+	Cause error
 }
 
 // Stopped is emitted when the application has finished shutting down, whether
@@ -214,6 +429,19 @@ type Stopped struct {
 	Err error
 }
 
+// ForceExiting is emitted when the application receives another signal while
+// it is shutting down with fx.ForceExitOnSecondSignal, right before it exits
+// without waiting for its remaining hooks.
+type ForceExiting struct {
+	// Signal is the signal that forced the exit.
+	Signal os.Signal
+
+	// CallerName is the name of the function that appended the hook that
+	// was running when the signal arrived, or empty if no hook was
+	// running.
+	CallerName string
+}
+
 // RollingBack is emitted when the application failed to start up due to an
 // error, and is being rolled back.
 type RollingBack struct {
@@ -228,6 +456,44 @@ type RolledBack struct {
 	Err error
 }
 
+// Restarting is emitted when App.Restart is called, before the application
+// is stopped.
+type Restarting struct{}
+
+// Restarted is emitted after App.Restart has stopped and started the
+// application, whether it succeeded or not.
+type Restarted struct {
+	// Err is non-nil if the application failed to restart.
+	Err error
+}
+
+// Reloading is emitted when the application is asked to reload, before its
+// OnReload hooks run. This may happen with App.Reload or by sending SIGHUP to
+// the application.
+type Reloading struct {
+	// Signal is the signal that caused this reload, or nil if App.Reload
+	// was called directly.
+	Signal os.Signal
+}
+
+// Reloaded is emitted after the application's OnReload hooks have run,
+// whether they succeeded or not.
+type Reloaded struct {
+	// Err is non-nil if any OnReload hook failed.
+	Err error
+}
+
+// StateChanged is emitted whenever the application's lifecycle moves from one
+// state to another, e.g. from "stopped" to "starting". The states are the
+// string forms of fx.State values.
+type StateChanged struct {
+	// From is the state the application was in.
+	From string
+
+	// To is the state the application is now in.
+	To string
+}
+
 // LoggerInitialized is emitted when a logger supplied with fx.WithLogger is
 // instantiated, or if it fails to instantiate.
 type LoggerInitialized struct {
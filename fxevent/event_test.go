@@ -33,6 +33,14 @@ func TestForCoverage(t *testing.T) {
 	events := []Event{
 		&OnStartExecuting{},
 		&OnStartExecuted{},
+		&OnStartRetrying{},
+		&OnStartSlow{},
+		&OnStopSlow{},
+		&GoroutineDump{},
+		&OnReadyExecuting{},
+		&OnReadyExecuted{},
+		&OnDrainExecuting{},
+		&OnDrainExecuted{},
 		&OnStopExecuting{},
 		&OnStopExecuted{},
 		&Supplied{},
@@ -41,11 +49,21 @@ func TestForCoverage(t *testing.T) {
 		&Decorated{},
 		&Invoking{},
 		&Invoked{},
+		&Unused{},
+		&Run{},
 		&Stopping{},
 		&Stopped{},
+		&ForceExiting{},
 		&RollingBack{},
 		&RolledBack{},
 		&Started{},
+		&StartSkipped{},
+		&BackgroundPanic{},
+		&Restarting{},
+		&Restarted{},
+		&Reloading{},
+		&Reloaded{},
+		&StateChanged{},
 		&LoggerInitialized{},
 	}
 
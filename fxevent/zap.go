@@ -22,6 +22,7 @@ package fxevent
 
 import (
 	"strings"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -72,6 +73,7 @@ func (l *ZapLogger) LogEvent(event Event) {
 			l.logError("OnStart hook failed",
 				zap.String("callee", e.FunctionName),
 				zap.String("caller", e.CallerName),
+				maybeDuration("timeout", e.Timeout),
 				zap.Error(e.Err),
 			)
 		} else {
@@ -81,6 +83,60 @@ func (l *ZapLogger) LogEvent(event Event) {
 				zap.String("runtime", e.Runtime.String()),
 			)
 		}
+	case *OnStartRetrying:
+		l.logEvent("OnStart hook failed, retrying",
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+			zap.Int("attempt", e.Attempt),
+			zap.String("backoff", e.Backoff.String()),
+			zap.Error(e.Err),
+		)
+	case *OnStartSlow:
+		l.logEvent("OnStart hook is running slowly",
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+			zap.String("elapsed", e.Elapsed.String()),
+		)
+	case *OnReadyExecuting:
+		l.logEvent("OnReady hook executing",
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+		)
+	case *OnReadyExecuted:
+		if e.Err != nil {
+			l.logError("OnReady hook failed",
+				zap.String("callee", e.FunctionName),
+				zap.String("caller", e.CallerName),
+				maybeDuration("timeout", e.Timeout),
+				zap.Error(e.Err),
+			)
+		} else {
+			l.logEvent("OnReady hook executed",
+				zap.String("callee", e.FunctionName),
+				zap.String("caller", e.CallerName),
+				zap.String("runtime", e.Runtime.String()),
+			)
+		}
+	case *OnDrainExecuting:
+		l.logEvent("OnDrain hook executing",
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+		)
+	case *OnDrainExecuted:
+		if e.Err != nil {
+			l.logError("OnDrain hook failed",
+				zap.String("callee", e.FunctionName),
+				zap.String("caller", e.CallerName),
+				maybeDuration("timeout", e.Timeout),
+				zap.Error(e.Err),
+			)
+		} else {
+			l.logEvent("OnDrain hook executed",
+				zap.String("callee", e.FunctionName),
+				zap.String("caller", e.CallerName),
+				zap.String("runtime", e.Runtime.String()),
+			)
+		}
 	case *OnStopExecuting:
 		l.logEvent("OnStop hook executing",
 			zap.String("callee", e.FunctionName),
@@ -91,6 +147,7 @@ func (l *ZapLogger) LogEvent(event Event) {
 			l.logError("OnStop hook failed",
 				zap.String("callee", e.FunctionName),
 				zap.String("caller", e.CallerName),
+				maybeDuration("timeout", e.Timeout),
 				zap.Error(e.Err),
 			)
 		} else {
@@ -100,6 +157,19 @@ func (l *ZapLogger) LogEvent(event Event) {
 				zap.String("runtime", e.Runtime.String()),
 			)
 		}
+	case *OnStopSlow:
+		l.logEvent("OnStop hook is running slowly",
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+			zap.String("elapsed", e.Elapsed.String()),
+		)
+	case *GoroutineDump:
+		l.logError("hook timed out",
+			zap.String("method", e.Method),
+			zap.String("callee", e.FunctionName),
+			zap.String("caller", e.CallerName),
+			zap.String("stack", e.Stack),
+		)
 	case *Supplied:
 		if e.Err != nil {
 			l.logError("error encountered while applying options",
@@ -151,6 +221,22 @@ func (l *ZapLogger) LogEvent(event Event) {
 				moduleField(e.ModuleName),
 				zap.Error(e.Err))
 		}
+	case *Run:
+		if e.Err != nil {
+			l.logError("error returned",
+				zap.String("name", e.Name),
+				zap.String("kind", e.Kind),
+				moduleField(e.ModuleName),
+				zap.Error(e.Err),
+			)
+		} else {
+			l.logEvent("run",
+				zap.String("name", e.Name),
+				zap.String("kind", e.Kind),
+				zap.String("runtime", e.Runtime.String()),
+				moduleField(e.ModuleName),
+			)
+		}
 	case *Invoking:
 		// Do not log stack as it will make logs hard to read.
 		l.logEvent("invoking",
@@ -166,13 +252,28 @@ func (l *ZapLogger) LogEvent(event Event) {
 				moduleField(e.ModuleName),
 			)
 		}
+	case *Unused:
+		for _, rtype := range e.OutputTypeNames {
+			l.logEvent("unused",
+				maybeString("constructor", e.ConstructorName),
+				moduleField(e.ModuleName),
+				zap.String("type", rtype),
+				zap.String("location", e.Location),
+			)
+		}
 	case *Stopping:
 		l.logEvent("received signal",
-			zap.String("signal", strings.ToUpper(e.Signal.String())))
+			zap.String("signal", strings.ToUpper(e.Signal.String())),
+			zap.NamedError("cause", e.Cause))
 	case *Stopped:
 		if e.Err != nil {
 			l.logError("stop failed", zap.Error(e.Err))
 		}
+	case *ForceExiting:
+		l.logError("received signal while stopping, forcing exit",
+			zap.String("signal", strings.ToUpper(e.Signal.String())),
+			maybeString("caller", e.CallerName),
+		)
 	case *RollingBack:
 		l.logError("start failed, rolling back", zap.Error(e.StartErr))
 	case *RolledBack:
@@ -185,6 +286,41 @@ func (l *ZapLogger) LogEvent(event Event) {
 		} else {
 			l.logEvent("started")
 		}
+	case *StartSkipped:
+		l.logEvent("shutdown requested before start, skipping start",
+			zap.String("signal", strings.ToUpper(e.Signal.String())))
+	case *BackgroundPanic:
+		l.logError("goroutine panicked",
+			zap.String("function", e.FunctionName),
+			zap.Any("panic", e.Recovered),
+			zap.String("stack", e.Stack),
+		)
+	case *Restarting:
+		l.logEvent("restarting")
+	case *Restarted:
+		if e.Err != nil {
+			l.logError("restart failed", zap.Error(e.Err))
+		} else {
+			l.logEvent("restarted")
+		}
+	case *Reloading:
+		if e.Signal != nil {
+			l.logEvent("reloading",
+				zap.String("signal", strings.ToUpper(e.Signal.String())))
+		} else {
+			l.logEvent("reloading")
+		}
+	case *Reloaded:
+		if e.Err != nil {
+			l.logError("reload failed", zap.Error(e.Err))
+		} else {
+			l.logEvent("reloaded")
+		}
+	case *StateChanged:
+		l.logEvent("state changed",
+			zap.String("from", e.From),
+			zap.String("to", e.To),
+		)
 	case *LoggerInitialized:
 		if e.Err != nil {
 			l.logError("custom logger initialization failed", zap.Error(e.Err))
@@ -201,6 +337,20 @@ func moduleField(name string) zap.Field {
 	return zap.String("module", name)
 }
 
+func maybeDuration(name string, d time.Duration) zap.Field {
+	if d > 0 {
+		return zap.String(name, d.String())
+	}
+	return zap.Skip()
+}
+
+func maybeString(name, s string) zap.Field {
+	if s != "" {
+		return zap.String(name, s)
+	}
+	return zap.Skip()
+}
+
 func maybeBool(name string, b bool) zap.Field {
 	if b {
 		return zap.Bool(name, true)
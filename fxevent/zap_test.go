@@ -114,6 +114,164 @@ func TestZapLogger(t *testing.T) {
 				"error":  "some error",
 			},
 		},
+		{
+			name: "OnStartExecuted/Timeout/Error",
+			give: &OnStartExecuted{
+				FunctionName: "hook.onStart1",
+				CallerName:   "bytes.NewBuffer",
+				Timeout:      time.Second,
+				Err:          fmt.Errorf("some error"),
+			},
+			wantMessage: "OnStart hook failed",
+			wantFields: map[string]interface{}{
+				"caller":  "bytes.NewBuffer",
+				"callee":  "hook.onStart1",
+				"timeout": "1s",
+				"error":   "some error",
+			},
+		},
+		{
+			name: "OnReadyExecuting",
+			give: &OnReadyExecuting{
+				FunctionName: "hook.onReady",
+				CallerName:   "bytes.NewBuffer",
+			},
+			wantMessage: "OnReady hook executing",
+			wantFields: map[string]interface{}{
+				"caller": "bytes.NewBuffer",
+				"callee": "hook.onReady",
+			},
+		},
+		{
+			name: "OnReadyExecuted/Error",
+			give: &OnReadyExecuted{
+				FunctionName: "hook.onReady",
+				CallerName:   "bytes.NewBuffer",
+				Err:          fmt.Errorf("some error"),
+			},
+			wantMessage: "OnReady hook failed",
+			wantFields: map[string]interface{}{
+				"caller": "bytes.NewBuffer",
+				"callee": "hook.onReady",
+				"error":  "some error",
+			},
+		},
+		{
+			name: "OnReadyExecuted",
+			give: &OnReadyExecuted{
+				FunctionName: "hook.onReady",
+				CallerName:   "bytes.NewBuffer",
+				Runtime:      time.Millisecond * 3,
+			},
+			wantMessage: "OnReady hook executed",
+			wantFields: map[string]interface{}{
+				"caller":  "bytes.NewBuffer",
+				"callee":  "hook.onReady",
+				"runtime": "3ms",
+			},
+		},
+		{
+			name: "OnDrainExecuting",
+			give: &OnDrainExecuting{
+				FunctionName: "hook.onDrain",
+				CallerName:   "bytes.NewBuffer",
+			},
+			wantMessage: "OnDrain hook executing",
+			wantFields: map[string]interface{}{
+				"caller": "bytes.NewBuffer",
+				"callee": "hook.onDrain",
+			},
+		},
+		{
+			name: "OnDrainExecuted/Error",
+			give: &OnDrainExecuted{
+				FunctionName: "hook.onDrain",
+				CallerName:   "bytes.NewBuffer",
+				Err:          fmt.Errorf("some error"),
+			},
+			wantMessage: "OnDrain hook failed",
+			wantFields: map[string]interface{}{
+				"caller": "bytes.NewBuffer",
+				"callee": "hook.onDrain",
+				"error":  "some error",
+			},
+		},
+		{
+			name: "OnDrainExecuted",
+			give: &OnDrainExecuted{
+				FunctionName: "hook.onDrain",
+				CallerName:   "bytes.NewBuffer",
+				Runtime:      time.Millisecond * 3,
+			},
+			wantMessage: "OnDrain hook executed",
+			wantFields: map[string]interface{}{
+				"caller":  "bytes.NewBuffer",
+				"callee":  "hook.onDrain",
+				"runtime": "3ms",
+			},
+		},
+		{
+			name: "OnStartSlow",
+			give: &OnStartSlow{
+				FunctionName: "hook.onStart1",
+				CallerName:   "bytes.NewBuffer",
+				Elapsed:      time.Second * 5,
+			},
+			wantMessage: "OnStart hook is running slowly",
+			wantFields: map[string]interface{}{
+				"caller":  "bytes.NewBuffer",
+				"callee":  "hook.onStart1",
+				"elapsed": "5s",
+			},
+		},
+		{
+			name: "OnStopSlow",
+			give: &OnStopSlow{
+				FunctionName: "hook.onStop1",
+				CallerName:   "bytes.NewBuffer",
+				Elapsed:      time.Second * 5,
+			},
+			wantMessage: "OnStop hook is running slowly",
+			wantFields: map[string]interface{}{
+				"caller":  "bytes.NewBuffer",
+				"callee":  "hook.onStop1",
+				"elapsed": "5s",
+			},
+		},
+		{
+			name: "GoroutineDump/Error",
+			give: &GoroutineDump{
+				Method:       "OnStart",
+				FunctionName: "hook.onStart1",
+				CallerName:   "bytes.NewBuffer",
+				Stack:        "goroutine 1 [select]:\nmain.main()",
+			},
+			wantMessage: "hook timed out",
+			wantFields: map[string]interface{}{
+				"method": "OnStart",
+				"caller": "bytes.NewBuffer",
+				"callee": "hook.onStart1",
+				"stack":  "goroutine 1 [select]:\nmain.main()",
+			},
+		},
+		{
+			name: "OnStartRetrying",
+			give: &OnStartRetrying{
+				FunctionName: "hook.onStart1",
+				CallerName:   "bytes.NewBuffer",
+				Attempt:      2,
+				Backoff:      time.Millisecond * 200,
+				Err:          fmt.Errorf("some error"),
+			},
+			wantMessage: "OnStart hook failed, retrying",
+			wantFields: map[string]interface{}{
+				"caller":  "bytes.NewBuffer",
+				"callee":  "hook.onStart1",
+				"attempt": int64(2),
+				"backoff": "200ms",
+				"error":   "some error",
+			},
+		},
 		{
 			name: "OnStartExecuted",
 			give: &OnStartExecuted{
@@ -227,6 +385,36 @@ func TestZapLogger(t *testing.T) {
 				"error": "some error",
 			},
 		},
+		{
+			name: "Run",
+			give: &Run{
+				Name:       "bytes.NewBuffer()",
+				Kind:       "provide",
+				ModuleName: "myModule",
+				Runtime:    time.Millisecond,
+			},
+			wantMessage: "run",
+			wantFields: map[string]interface{}{
+				"name":    "bytes.NewBuffer()",
+				"kind":    "provide",
+				"module":  "myModule",
+				"runtime": "1ms",
+			},
+		},
+		{
+			name: "Run/Error",
+			give: &Run{
+				Name: "bytes.NewBuffer()",
+				Kind: "provide",
+				Err:  someError,
+			},
+			wantMessage: "error returned",
+			wantFields: map[string]interface{}{
+				"name":  "bytes.NewBuffer()",
+				"kind":  "provide",
+				"error": "some error",
+			},
+		},
 		{
 			name:        "Invoking/Success",
 			give:        &Invoking{ModuleName: "myModule", FunctionName: "bytes.NewBuffer()"},
@@ -246,6 +434,22 @@ func TestZapLogger(t *testing.T) {
 				"function": "bytes.NewBuffer()",
 			},
 		},
+		{
+			name: "Unused",
+			give: &Unused{
+				ConstructorName: "bytes.NewBuffer()",
+				OutputTypeNames: []string{"*bytes.Buffer"},
+				ModuleName:      "myModule",
+				Location:        "main.main (main.go:42)",
+			},
+			wantMessage: "unused",
+			wantFields: map[string]interface{}{
+				"constructor": "bytes.NewBuffer()",
+				"module":      "myModule",
+				"type":        "*bytes.Buffer",
+				"location":    "main.main (main.go:42)",
+			},
+		},
 		{
 			name:        "Start/Error",
 			give:        &Started{Err: someError},
@@ -262,6 +466,15 @@ func TestZapLogger(t *testing.T) {
 				"signal": "INTERRUPT",
 			},
 		},
+		{
+			name:        "Stopping/Cause",
+			give:        &Stopping{Signal: os.Interrupt, Cause: someError},
+			wantMessage: "received signal",
+			wantFields: map[string]interface{}{
+				"signal": "INTERRUPT",
+				"cause":  "some error",
+			},
+		},
 		{
 			name:        "Stopped/Error",
 			give:        &Stopped{Err: someError},
@@ -270,6 +483,23 @@ func TestZapLogger(t *testing.T) {
 				"error": "some error",
 			},
 		},
+		{
+			name:        "ForceExiting/Error",
+			give:        &ForceExiting{Signal: os.Interrupt, CallerName: "bytes.NewBuffer"},
+			wantMessage: "received signal while stopping, forcing exit",
+			wantFields: map[string]interface{}{
+				"signal": "INTERRUPT",
+				"caller": "bytes.NewBuffer",
+			},
+		},
+		{
+			name:        "ForceExiting/NoHook/Error",
+			give:        &ForceExiting{Signal: os.Interrupt},
+			wantMessage: "received signal while stopping, forcing exit",
+			wantFields: map[string]interface{}{
+				"signal": "INTERRUPT",
+			},
+		},
 		{
 			name:        "RollingBack/Error",
 			give:        &RollingBack{StartErr: someError},
@@ -292,6 +522,81 @@ func TestZapLogger(t *testing.T) {
 			wantMessage: "started",
 			wantFields:  map[string]interface{}{},
 		},
+		{
+			name:        "StartSkipped",
+			give:        &StartSkipped{Signal: os.Interrupt},
+			wantMessage: "shutdown requested before start, skipping start",
+			wantFields: map[string]interface{}{
+				"signal": "INTERRUPT",
+			},
+		},
+		{
+			name:        "BackgroundPanic/Error",
+			give:        &BackgroundPanic{FunctionName: "bytes.NewBuffer", Recovered: "great sadness", Stack: "stack"},
+			wantMessage: "goroutine panicked",
+			wantFields: map[string]interface{}{
+				"function": "bytes.NewBuffer",
+				"panic":    "great sadness",
+				"stack":    "stack",
+			},
+		},
+		{
+			name:        "Restarting",
+			give:        &Restarting{},
+			wantMessage: "restarting",
+			wantFields:  map[string]interface{}{},
+		},
+		{
+			name:        "Restarted",
+			give:        &Restarted{},
+			wantMessage: "restarted",
+			wantFields:  map[string]interface{}{},
+		},
+		{
+			name:        "Restarted/Error",
+			give:        &Restarted{Err: someError},
+			wantMessage: "restart failed",
+			wantFields: map[string]interface{}{
+				"error": "some error",
+			},
+		},
+		{
+			name:        "Reloading",
+			give:        &Reloading{},
+			wantMessage: "reloading",
+			wantFields:  map[string]interface{}{},
+		},
+		{
+			name:        "Reloading/Signal",
+			give:        &Reloading{Signal: os.Interrupt},
+			wantMessage: "reloading",
+			wantFields: map[string]interface{}{
+				"signal": "INTERRUPT",
+			},
+		},
+		{
+			name:        "Reloaded",
+			give:        &Reloaded{},
+			wantMessage: "reloaded",
+			wantFields:  map[string]interface{}{},
+		},
+		{
+			name:        "Reloaded/Error",
+			give:        &Reloaded{Err: someError},
+			wantMessage: "reload failed",
+			wantFields: map[string]interface{}{
+				"error": "some error",
+			},
+		},
+		{
+			name:        "StateChanged",
+			give:        &StateChanged{From: "stopped", To: "starting"},
+			wantMessage: "state changed",
+			wantFields: map[string]interface{}{
+				"from": "stopped",
+				"to":   "starting",
+			},
+		},
 		{
 			name:        "LoggerInitialized/Error",
 			give:        &LoggerInitialized{Err: someError},
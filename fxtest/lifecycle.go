@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"time"
 
 	"go.uber.org/fx"
 	"go.uber.org/fx/internal/fxclock"
@@ -128,10 +129,28 @@ func (l *Lifecycle) RequireStop() {
 	}
 }
 
+// Reload calls all OnReload hooks in order. The lifecycle must have been
+// started.
+//
+// If any hook returns an error, execution continues with the remaining
+// hooks. Any errors encountered are collected into a single error and
+// returned.
+func (l *Lifecycle) Reload(ctx context.Context) error { return l.lc.Reload(ctx) }
+
 // Append registers a new Hook.
 func (l *Lifecycle) Append(h fx.Hook) {
+	var startRetry func(int) (time.Duration, bool)
+	if h.StartRetry != nil {
+		startRetry = h.StartRetry.Backoff
+	}
+
 	l.lc.Append(lifecycle.Hook{
-		OnStart: h.OnStart,
-		OnStop:  h.OnStop,
+		OnStart:    h.OnStart,
+		OnStop:     h.OnStop,
+		OnReady:    h.OnReady,
+		OnDrain:    h.OnDrain,
+		OnReload:   h.OnReload,
+		Timeout:    h.Timeout,
+		StartRetry: startRetry,
 	})
 }
@@ -26,6 +26,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -81,6 +82,52 @@ func TestLifecycle(t *testing.T) {
 		assert.Equal(t, 1, spy.failures, "Expected lifecycle stop to fail.")
 	})
 
+	t.Run("StartRetry", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		lc := NewLifecycle(spy)
+
+		var attempts int
+		lc.Append(fx.Hook{
+			OnStart: func(context.Context) error {
+				attempts++
+				if attempts < 3 {
+					return errors.New("not yet")
+				}
+				return nil
+			},
+			StartRetry: &fx.RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+			},
+		})
+		lc.RequireStart().RequireStop()
+
+		assert.Zero(t, spy.failures, "Lifecycle start/stop failed.")
+		assert.Equal(t, 3, attempts, "OnStart wasn't retried.")
+	})
+
+	t.Run("Reload", func(t *testing.T) {
+		t.Parallel()
+
+		spy := newTB()
+		lc := NewLifecycle(spy)
+
+		var reloads int
+		lc.Append(fx.ReloadHook(func() { reloads++ }))
+
+		ctx := context.Background()
+		assert.Error(t, lc.Reload(ctx), "must not reload before start")
+
+		lc.RequireStart()
+		require.NoError(t, lc.Reload(ctx))
+		lc.RequireStop()
+
+		assert.Zero(t, spy.failures, "Lifecycle start/stop failed.")
+		assert.Equal(t, 1, reloads, "Didn't run reload hook.")
+	})
+
 	t.Run("RequireLeakDetection", func(t *testing.T) {
 		t.Parallel()
 
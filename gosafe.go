@@ -0,0 +1,118 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxreflect"
+)
+
+// DefaultPanicExitCode is the code that App.Run exits with after a goroutine
+// started with GoSafe panics, like a Go program that panics. It can be
+// configured with the PanicExitCode option.
+const DefaultPanicExitCode = 2
+
+// GoSafe runs background work for the application, such as the goroutines
+// that OnStart hooks start to serve requests or consume queues. Unlike a bare
+// go statement, a panic in such a goroutine doesn't kill the process: GoSafe
+// recovers it, reports it as an fxevent.BackgroundPanic event, and shuts the
+// application down with Shutdowner, so that its OnStop hooks still run.
+//
+// GoSafe is provided to all Fx applications.
+//
+//	fx.Invoke(func(lc fx.Lifecycle, gs fx.GoSafe, c *Consumer) {
+//		ctx, cancel := context.WithCancel(context.Background())
+//		lc.Append(fx.StartStopHook(
+//			func() { gs.Go(ctx, c.Consume) },
+//			cancel,
+//		))
+//	})
+type GoSafe interface {
+	// Go calls fn with ctx in a new goroutine. If fn panics, the
+	// application shuts down with a ShutdownCause describing the panic
+	// and the exit code given to PanicExitCode.
+	Go(ctx context.Context, fn func(context.Context))
+}
+
+type goSafe struct{ app *App }
+
+func (g goSafe) Go(ctx context.Context, fn func(context.Context)) {
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				g.app.backgroundPanic(fn, r, debug.Stack())
+			}
+		}()
+
+		fn(ctx)
+	}()
+}
+
+func (app *App) goSafe() GoSafe {
+	return goSafe{app: app}
+}
+
+// backgroundPanic reports that fn panicked with the given value and shuts the
+// application down.
+func (app *App) backgroundPanic(fn func(context.Context), r interface{}, stack []byte) {
+	name := fxreflect.FuncName(fn)
+	app.log().LogEvent(&fxevent.BackgroundPanic{
+		FunctionName: name,
+		Recovered:    r,
+		Stack:        string(stack),
+	})
+
+	var err error
+	if rerr, ok := r.(error); ok {
+		err = fmt.Errorf("goroutine %v panicked: %w", name, rerr)
+	} else {
+		err = fmt.Errorf("goroutine %v panicked: %v", name, r)
+	}
+
+	// Nobody may be listening on Done or Wait yet; they'll still get the
+	// signal when they do.
+	_ = app.shutdowner().Shutdown(ExitCode(app.panicExitCode), ShutdownCause(err))
+}
+
+// PanicExitCode specifies the code that App.Run exits with after a goroutine
+// started with GoSafe panics. It defaults to DefaultPanicExitCode.
+func PanicExitCode(code int) Option {
+	return panicExitCodeOption(code)
+}
+
+type panicExitCodeOption int
+
+func (o panicExitCodeOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.PanicExitCode Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.panicExitCode = int(o)
+	}
+}
+
+func (o panicExitCodeOption) String() string {
+	return fmt.Sprintf("fx.PanicExitCode(%d)", int(o))
+}
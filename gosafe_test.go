@@ -0,0 +1,116 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+func TestGoSafe(t *testing.T) {
+	t.Parallel()
+
+	errSad := errors.New("great sadness")
+	panics := func(context.Context) { panic(errSad) }
+
+	t.Run("panic shuts down", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped bool
+		app, spy := NewSpied(
+			WithExit(func(int) { assert.Fail(t, "must not exit") }),
+			Invoke(func(lc Lifecycle, gs GoSafe) {
+				lc.Append(StartStopHook(
+					func(ctx context.Context) { gs.Go(context.Background(), panics) },
+					func() { stopped = true },
+				))
+			}),
+		)
+
+		err := app.RunContext(context.Background())
+		assert.ErrorIs(t, err, errSad)
+		assert.Contains(t, err.Error(), "panicked: great sadness")
+		var exit interface{ ExitCode() int }
+		require.ErrorAs(t, err, &exit)
+		assert.Equal(t, DefaultPanicExitCode, exit.ExitCode())
+		assert.True(t, stopped, "OnStop hook must run")
+
+		events := spy.Events().SelectByTypeName("BackgroundPanic")
+		require.Len(t, events, 1)
+		e := events[0].(*fxevent.BackgroundPanic)
+		assert.Contains(t, e.FunctionName, "TestGoSafe")
+		assert.Equal(t, errSad, e.Recovered)
+		assert.Contains(t, e.Stack, "TestGoSafe")
+	})
+
+	t.Run("exit code", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			PanicExitCode(70),
+			Invoke(func(gs GoSafe) {
+				gs.Go(context.Background(), func(context.Context) {
+					panic("great sadness")
+				})
+			}),
+		)
+
+		sig := <-app.Wait()
+		assert.Equal(t, 70, sig.ExitCode)
+		assert.ErrorContains(t, sig.Cause, "panicked: great sadness")
+	})
+
+	t.Run("no panic", func(t *testing.T) {
+		t.Parallel()
+
+		done := make(chan struct{})
+		ctx := context.WithValue(context.Background(), done, "value")
+		app := New(
+			NopLogger,
+			Invoke(func(gs GoSafe) {
+				gs.Go(ctx, func(ctx context.Context) {
+					assert.Equal(t, "value", ctx.Value(done))
+					close(done)
+				})
+			}),
+		)
+		<-done
+
+		require.NoError(t, app.Start(context.Background()))
+		assert.Equal(t, StateStarted, app.State())
+		require.NoError(t, app.Stop(context.Background()))
+	})
+
+	t.Run("in module", func(t *testing.T) {
+		t.Parallel()
+
+		err := New(NopLogger, Module("foo", PanicExitCode(3))).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.PanicExitCode Option should be passed to top-level App")
+	})
+}
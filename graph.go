@@ -0,0 +1,348 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"go.uber.org/dig"
+	"go.uber.org/fx/internal/fxreflect"
+)
+
+// Graph describes the dependency graph of an application in terms of the
+// constructors and decorators provided to it. Unlike DotGraph, which is meant
+// to be rendered, Graph is meant to be inspected by tools and tests.
+type Graph struct {
+	// Constructors lists the constructors and supplied values that were
+	// successfully provided, in the order they were provided. This
+	// includes the values that Fx provides to all applications, such as
+	// Lifecycle and Shutdowner.
+	Constructors []*GraphConstructor
+
+	// Decorators lists the decorators and replacements that were
+	// successfully applied, in the order they were applied.
+	Decorators []*GraphDecorator
+
+	// Edges lists the dependencies between constructors.
+	Edges []GraphEdge
+}
+
+// GraphConstructor describes a constructor provided with fx.Provide, or a
+// value provided with fx.Supply.
+type GraphConstructor struct {
+	// FunctionName is the name of the constructor. It is empty for
+	// supplied values.
+	FunctionName string
+
+	// ModulePath lists the names of the modules the constructor was
+	// provided in, outermost first. It is empty for constructors provided
+	// at the top level of the application.
+	ModulePath []string
+
+	// Private is true if the constructor was provided with fx.Private.
+	Private bool
+
+	// Supply is true if the value was provided with fx.Supply.
+	Supply bool
+
+	// CallerName is the name of the function that called fx.Provide or
+	// fx.Supply, and CallerFile and CallerLine identify where it did so.
+	CallerName string
+	CallerFile string
+	CallerLine int
+
+	// Inputs and Outputs list the values the constructor consumes and
+	// produces.
+	Inputs  []GraphInput
+	Outputs []GraphOutput
+
+	// Invoked is true if the constructor was called. Fx calls
+	// constructors lazily, so constructors whose values aren't needed by
+	// an fx.Invoke are never called.
+	Invoked bool
+}
+
+// GraphDecorator describes a decorator provided with fx.Decorate, or a
+// replacement provided with fx.Replace.
+type GraphDecorator struct {
+	// FunctionName is the name of the decorator. It is empty for
+	// replacements.
+	FunctionName string
+
+	// ModulePath lists the names of the modules the decorator was
+	// provided in, outermost first. It is empty for decorators provided
+	// at the top level of the application.
+	ModulePath []string
+
+	// Replace is true if the decorator was provided with fx.Replace.
+	Replace bool
+
+	// CallerName is the name of the function that called fx.Decorate or
+	// fx.Replace, and CallerFile and CallerLine identify where it did so.
+	CallerName string
+	CallerFile string
+	CallerLine int
+
+	// Inputs and Outputs list the values the decorator consumes and
+	// produces.
+	Inputs  []GraphInput
+	Outputs []GraphOutput
+}
+
+// GraphInput describes a value consumed by a constructor or decorator.
+type GraphInput struct {
+	// Type is the type of the value. For value groups, this is the type
+	// of the slice the values are consumed as.
+	Type string `json:"type"`
+
+	// Name and Group are the name or value group of the value, if any.
+	Name  string `json:"name,omitempty"`
+	Group string `json:"group,omitempty"`
+
+	// Optional is true if the value was marked optional.
+	Optional bool `json:"optional,omitempty"`
+}
+
+// String renders the input in the same form as Fx's log messages, as in,
+//
+//	*bytes.Buffer[optional, name = "foo"]
+func (in GraphInput) String() string {
+	var tags []string
+	if in.Optional {
+		tags = append(tags, "optional")
+	}
+	return in.Type + formatTags(append(tags, nameTags(in.Name, in.Group)...))
+}
+
+// GraphOutput describes a value produced by a constructor or decorator.
+type GraphOutput struct {
+	// Type is the type of the value.
+	Type string `json:"type"`
+
+	// Name and Group are the name or value group of the value, if any.
+	Name  string `json:"name,omitempty"`
+	Group string `json:"group,omitempty"`
+}
+
+// String renders the output in the same form as Fx's log messages, as in,
+//
+//	*bytes.Buffer[name = "foo"]
+func (out GraphOutput) String() string {
+	return out.Type + formatTags(nameTags(out.Name, out.Group))
+}
+
+func nameTags(name, group string) []string {
+	var tags []string
+	if name != "" {
+		tags = append(tags, fmt.Sprintf("name = %q", name))
+	}
+	if group != "" {
+		tags = append(tags, fmt.Sprintf("group = %q", group))
+	}
+	return tags
+}
+
+func formatTags(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+	return "[" + strings.Join(tags, ", ") + "]"
+}
+
+// GraphEdge records that one constructor consumes a value produced by
+// another. Like the container, edges only lead to constructors whose values
+// are visible from the consumer's module: a private constructor in another
+// module never supplies it. A constructor that consumes a value group has
+// an edge to each constructor that feeds into the group.
+type GraphEdge struct {
+	Consumer *GraphConstructor
+	Producer *GraphConstructor
+
+	// Input is the input of Consumer that Producer supplies.
+	Input GraphInput
+}
+
+// Introspector provides access to the dependency graph of an application.
+//
+// Introspector is provided to all Fx applications. Constructors that are
+// yet to run are still listed as not invoked at the time Graph is called.
+type Introspector interface {
+	Graph() *Graph
+}
+
+type introspector struct{ app *App }
+
+func (i introspector) Graph() *Graph {
+	return i.app.Graph()
+}
+
+func (app *App) introspector() Introspector {
+	return introspector{app: app}
+}
+
+// Graph returns a description of the application's dependency graph.
+//
+// If the application failed to build, the graph includes the constructors
+// and decorators that were provided before the failure.
+func (app *App) Graph() *Graph {
+	var g Graph
+	nodes := make(map[*constructorNode]*GraphConstructor, len(app.constructors))
+	for _, n := range app.constructors {
+		c := &GraphConstructor{
+			ModulePath: n.module.path(),
+			Private:    n.provide.Private,
+			Supply:     n.provide.IsSupply,
+			Inputs:     graphInputs(n.info.Inputs),
+			Outputs:    graphOutputs(n.info.Outputs),
+			Invoked:    n.invoked,
+		}
+		if !n.provide.IsSupply {
+			c.FunctionName = fxreflect.FuncName(n.provide.Target)
+		}
+		if len(n.provide.Stack) > 0 {
+			f := n.provide.Stack[0]
+			c.CallerName, c.CallerFile, c.CallerLine = f.Function, f.File, f.Line
+		}
+		nodes[n] = c
+		g.Constructors = append(g.Constructors, c)
+	}
+
+	for _, n := range app.constructors {
+		consumer := nodes[n]
+		for i, in := range n.inputs {
+			for _, p := range app.visibleProviders(n.module, in) {
+				g.Edges = append(g.Edges, GraphEdge{
+					Consumer: consumer,
+					Producer: nodes[p],
+					Input:    consumer.Inputs[i],
+				})
+			}
+		}
+	}
+
+	for _, n := range app.decorators {
+		d := &GraphDecorator{
+			ModulePath: n.module.path(),
+			Replace:    n.decorator.IsReplace,
+			Inputs:     graphInputs(n.info.Inputs),
+			Outputs:    graphOutputs(n.info.Outputs),
+		}
+		if !n.decorator.IsReplace {
+			d.FunctionName = fxreflect.FuncName(n.decorator.Target)
+		}
+		if len(n.decorator.Stack) > 0 {
+			f := n.decorator.Stack[0]
+			d.CallerName, d.CallerFile, d.CallerLine = f.Function, f.File, f.Line
+		}
+		g.Decorators = append(g.Decorators, d)
+	}
+
+	return &g
+}
+
+func graphInputs(ins []*dig.Input) []GraphInput {
+	inputs := make([]GraphInput, len(ins))
+	for i, in := range ins {
+		var tags []string
+		inputs[i].Type, tags = splitTags(in.String())
+		for _, tag := range tags {
+			switch {
+			case tag == "optional":
+				inputs[i].Optional = true
+			case strings.HasPrefix(tag, "name = "):
+				inputs[i].Name = unquoteTag(tag, "name = ")
+			case strings.HasPrefix(tag, "group = "):
+				inputs[i].Group = unquoteTag(tag, "group = ")
+			}
+		}
+	}
+	return inputs
+}
+
+func graphOutputs(outs []*dig.Output) []GraphOutput {
+	outputs := make([]GraphOutput, len(outs))
+	for i, out := range outs {
+		var tags []string
+		outputs[i].Type, tags = splitTags(out.String())
+		for _, tag := range tags {
+			switch {
+			case strings.HasPrefix(tag, "name = "):
+				outputs[i].Name = unquoteTag(tag, "name = ")
+			case strings.HasPrefix(tag, "group = "):
+				outputs[i].Group = unquoteTag(tag, "group = ")
+			}
+		}
+	}
+	return outputs
+}
+
+// splitTags splits the string form of a dig.Input or dig.Output, such as,
+//
+//	*bytes.Buffer[optional, name = "foo"]
+//
+// into the type and its tags. dig doesn't otherwise expose the tags.
+func splitTags(s string) (typ string, tags []string) {
+	if !strings.HasSuffix(s, "]") {
+		return s, nil
+	}
+
+	// Tags are always listed in this order, so the first one present
+	// starts the tag list. Types may contain brackets themselves, as in
+	// []string or map[string]int.
+	for _, first := range []string{"[optional", `[name = "`, `[group = "`} {
+		i := strings.LastIndex(s, first)
+		if i < 0 {
+			continue
+		}
+
+		typ, rest := s[:i], s[i+1:len(s)-1]
+		for len(rest) > 0 {
+			var tag string
+			if strings.HasPrefix(rest, "optional") {
+				tag = "optional"
+			} else if j := strings.Index(rest, `"`); j >= 0 {
+				// Names and groups are quoted and may contain
+				// commas.
+				q, err := strconv.QuotedPrefix(rest[j:])
+				if err != nil {
+					return s, nil
+				}
+				tag = rest[:j] + q
+			} else {
+				return s, nil
+			}
+			tags = append(tags, tag)
+			rest = strings.TrimPrefix(rest[len(tag):], ", ")
+		}
+		return typ, tags
+	}
+	return s, nil
+}
+
+func unquoteTag(tag, prefix string) string {
+	s, err := strconv.Unquote(strings.TrimPrefix(tag, prefix))
+	if err != nil {
+		return ""
+	}
+	return s
+}
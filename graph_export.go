@@ -0,0 +1,328 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GraphFilter reports whether a constructor or decorator belongs in a
+// filtered graph, given the modules it was provided in and the values it
+// produces. Use it with Graph.Filter.
+type GraphFilter func(modulePath []string, outputs []GraphOutput) bool
+
+// GraphModuleFilter selects the constructors and decorators provided in the
+// module with the given name, including those provided in its submodules.
+func GraphModuleFilter(name string) GraphFilter {
+	return func(modulePath []string, _ []GraphOutput) bool {
+		for _, m := range modulePath {
+			if m == name {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// GraphTypeFilter selects the constructors and decorators that produce a
+// value whose type starts with the given prefix. Pointer and slice markers
+// are ignored, so the prefix "http." matches both *http.Server and
+// []http.Handler.
+func GraphTypeFilter(prefix string) GraphFilter {
+	return func(_ []string, outputs []GraphOutput) bool {
+		for _, out := range outputs {
+			if strings.HasPrefix(strings.TrimLeft(out.Type, "*[]"), prefix) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Filter returns a copy of the graph holding only the constructors and
+// decorators selected by all the given filters, and the edges between the
+// remaining constructors.
+func (g *Graph) Filter(filters ...GraphFilter) *Graph {
+	keep := func(modulePath []string, outputs []GraphOutput) bool {
+		for _, f := range filters {
+			if !f(modulePath, outputs) {
+				return false
+			}
+		}
+		return true
+	}
+
+	var filtered Graph
+	kept := make(map[*GraphConstructor]struct{})
+	for _, c := range g.Constructors {
+		if keep(c.ModulePath, c.Outputs) {
+			kept[c] = struct{}{}
+			filtered.Constructors = append(filtered.Constructors, c)
+		}
+	}
+	for _, d := range g.Decorators {
+		if keep(d.ModulePath, d.Outputs) {
+			filtered.Decorators = append(filtered.Decorators, d)
+		}
+	}
+	for _, e := range g.Edges {
+		_, consumer := kept[e.Consumer]
+		_, producer := kept[e.Producer]
+		if consumer && producer {
+			filtered.Edges = append(filtered.Edges, e)
+		}
+	}
+	return &filtered
+}
+
+// graphCluster groups the constructors and decorators provided in a module.
+// Constructors and decorators are identified by their position in the
+// Graph.
+type graphCluster struct {
+	name         string
+	constructors []int
+	decorators   []int
+	modules      []*graphCluster
+}
+
+// clusters arranges the graph into a tree of modules, rooted at the
+// top-level of the application.
+func (g *Graph) clusters() *graphCluster {
+	root := new(graphCluster)
+	find := func(path []string) *graphCluster {
+		c := root
+	next:
+		for _, name := range path {
+			for _, m := range c.modules {
+				if m.name == name {
+					c = m
+					continue next
+				}
+			}
+			m := &graphCluster{name: name}
+			c.modules = append(c.modules, m)
+			c = m
+		}
+		return c
+	}
+
+	for i, c := range g.Constructors {
+		m := find(c.ModulePath)
+		m.constructors = append(m.constructors, i)
+	}
+	for i, d := range g.Decorators {
+		m := find(d.ModulePath)
+		m.decorators = append(m.decorators, i)
+	}
+	return root
+}
+
+type graphJSON struct {
+	Module graphModuleJSON `json:"module"`
+	Edges  []graphEdgeJSON `json:"edges"`
+}
+
+type graphModuleJSON struct {
+	Name         string                 `json:"name,omitempty"`
+	Constructors []graphConstructorJSON `json:"constructors"`
+	Decorators   []graphDecoratorJSON   `json:"decorators"`
+	Modules      []graphModuleJSON      `json:"modules"`
+}
+
+type graphConstructorJSON struct {
+	ID           int           `json:"id"`
+	FunctionName string        `json:"function,omitempty"`
+	Private      bool          `json:"private,omitempty"`
+	Supply       bool          `json:"supply,omitempty"`
+	CallerName   string        `json:"caller,omitempty"`
+	CallerFile   string        `json:"file,omitempty"`
+	CallerLine   int           `json:"line,omitempty"`
+	Inputs       []GraphInput  `json:"inputs"`
+	Outputs      []GraphOutput `json:"outputs"`
+	Invoked      bool          `json:"invoked"`
+}
+
+type graphDecoratorJSON struct {
+	ID           int           `json:"id"`
+	FunctionName string        `json:"function,omitempty"`
+	Replace      bool          `json:"replace,omitempty"`
+	CallerName   string        `json:"caller,omitempty"`
+	CallerFile   string        `json:"file,omitempty"`
+	CallerLine   int           `json:"line,omitempty"`
+	Inputs       []GraphInput  `json:"inputs"`
+	Outputs      []GraphOutput `json:"outputs"`
+}
+
+type graphEdgeJSON struct {
+	Consumer int        `json:"consumer"`
+	Producer int        `json:"producer"`
+	Input    GraphInput `json:"input"`
+}
+
+// MarshalJSON renders the graph as a JSON object in the form,
+//
+//	{
+//	  "module": {
+//	    "constructors": [{"id": 0, "function": "...", ...}, ...],
+//	    "decorators": [{"id": 0, "function": "...", ...}, ...],
+//	    "modules": [{"name": "...", "constructors": [...], ...}, ...]
+//	  },
+//	  "edges": [{"consumer": 1, "producer": 0, "input": {...}}, ...]
+//	}
+//
+// where constructors and decorators are nested inside the modules they were
+// provided in, and edges refer to constructors by their IDs. IDs are
+// positions in the Constructors and Decorators fields of the graph.
+func (g *Graph) MarshalJSON() ([]byte, error) {
+	ids := make(map[*GraphConstructor]int, len(g.Constructors))
+	for i, c := range g.Constructors {
+		ids[c] = i
+	}
+
+	edges := make([]graphEdgeJSON, len(g.Edges))
+	for i, e := range g.Edges {
+		edges[i] = graphEdgeJSON{
+			Consumer: ids[e.Consumer],
+			Producer: ids[e.Producer],
+			Input:    e.Input,
+		}
+	}
+
+	return json.Marshal(graphJSON{
+		Module: g.moduleJSON(g.clusters()),
+		Edges:  edges,
+	})
+}
+
+func (g *Graph) moduleJSON(m *graphCluster) graphModuleJSON {
+	out := graphModuleJSON{
+		Name:         m.name,
+		Constructors: make([]graphConstructorJSON, len(m.constructors)),
+		Decorators:   make([]graphDecoratorJSON, len(m.decorators)),
+		Modules:      make([]graphModuleJSON, len(m.modules)),
+	}
+	for i, id := range m.constructors {
+		c := g.Constructors[id]
+		out.Constructors[i] = graphConstructorJSON{
+			ID:           id,
+			FunctionName: c.FunctionName,
+			Private:      c.Private,
+			Supply:       c.Supply,
+			CallerName:   c.CallerName,
+			CallerFile:   c.CallerFile,
+			CallerLine:   c.CallerLine,
+			Inputs:       c.Inputs,
+			Outputs:      c.Outputs,
+			Invoked:      c.Invoked,
+		}
+	}
+	for i, id := range m.decorators {
+		d := g.Decorators[id]
+		out.Decorators[i] = graphDecoratorJSON{
+			ID:           id,
+			FunctionName: d.FunctionName,
+			Replace:      d.Replace,
+			CallerName:   d.CallerName,
+			CallerFile:   d.CallerFile,
+			CallerLine:   d.CallerLine,
+			Inputs:       d.Inputs,
+			Outputs:      d.Outputs,
+		}
+	}
+	for i, sub := range m.modules {
+		out.Modules[i] = g.moduleJSON(sub)
+	}
+	return out
+}
+
+// Mermaid renders the graph as a Mermaid flowchart. Each constructor is a
+// node labeled with the values it produces, each decorator is a hexagon
+// labeled with the values it decorates, and each module is a subgraph.
+// Arrows point from the constructor producing a value to the constructors
+// consuming it.
+//
+// Use Filter to keep diagrams of large applications readable.
+func (g *Graph) Mermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	var subgraphs int
+	g.writeMermaidCluster(&b, g.clusters(), "\t", &subgraphs)
+
+	ids := make(map[*GraphConstructor]int, len(g.Constructors))
+	for i, c := range g.Constructors {
+		ids[c] = i
+	}
+	for _, e := range g.Edges {
+		var label string
+		switch {
+		case e.Input.Name != "":
+			label = fmt.Sprintf("|%s|", mermaidQuote("name: "+e.Input.Name))
+		case e.Input.Group != "":
+			label = fmt.Sprintf("|%s|", mermaidQuote("group: "+e.Input.Group))
+		}
+		arrow := "-->"
+		if e.Input.Optional {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "\tc%d %s%s c%d\n", ids[e.Producer], arrow, label, ids[e.Consumer])
+	}
+	return b.String()
+}
+
+// writeMermaidCluster writes the nodes of the given module and its
+// submodules. Subgraph IDs must be unique, so subgraphs are numbered with
+// the given counter.
+func (g *Graph) writeMermaidCluster(b *strings.Builder, m *graphCluster, indent string, subgraphs *int) {
+	for _, id := range m.constructors {
+		c := g.Constructors[id]
+		label := outputsLabel(c.Outputs)
+		if c.Private {
+			label += " (private)"
+		}
+		fmt.Fprintf(b, "%sc%d[%s]\n", indent, id, mermaidQuote(label))
+	}
+	for _, id := range m.decorators {
+		d := g.Decorators[id]
+		fmt.Fprintf(b, "%sd%d{{%s}}\n", indent, id, mermaidQuote(outputsLabel(d.Outputs)))
+	}
+	for _, sub := range m.modules {
+		fmt.Fprintf(b, "%ssubgraph m%d [%s]\n", indent, *subgraphs, mermaidQuote(sub.name))
+		*subgraphs++
+		g.writeMermaidCluster(b, sub, indent+"\t", subgraphs)
+		fmt.Fprintf(b, "%send\n", indent)
+	}
+}
+
+func outputsLabel(outputs []GraphOutput) string {
+	items := make([]string, len(outputs))
+	for i, out := range outputs {
+		items[i] = out.String()
+	}
+	return strings.Join(items, "<br/>")
+}
+
+// mermaidQuote quotes s for use as a Mermaid label. Mermaid has no escape
+// for double quotes inside labels other than its #quot; entity.
+func mermaidQuote(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, "#quot;") + `"`
+}
@@ -0,0 +1,204 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+// newExportGraph builds a graph by hand so that exports are deterministic.
+//
+//	*A <- *B (in module "outer/inner", private), consumed by *C
+func newExportGraph() *Graph {
+	a := &GraphConstructor{
+		Supply:  true,
+		Outputs: []GraphOutput{{Type: "*fx_test.A"}},
+		Inputs:  []GraphInput{},
+		Invoked: true,
+	}
+	b := &GraphConstructor{
+		FunctionName: "fx_test.NewB",
+		ModulePath:   []string{"outer", "inner"},
+		Private:      true,
+		Inputs:       []GraphInput{{Type: "*fx_test.A", Name: "a"}},
+		Outputs:      []GraphOutput{{Type: "*fx_test.B"}},
+		Invoked:      true,
+	}
+	c := &GraphConstructor{
+		FunctionName: "fx_test.NewC",
+		ModulePath:   []string{"outer"},
+		Inputs:       []GraphInput{{Type: "*fx_test.B", Optional: true}},
+		Outputs:      []GraphOutput{{Type: "*fx_test.C", Group: "cs"}},
+	}
+	return &Graph{
+		Constructors: []*GraphConstructor{a, b, c},
+		Decorators: []*GraphDecorator{{
+			FunctionName: "fx_test.DecorateA",
+			ModulePath:   []string{"other"},
+			Inputs:       []GraphInput{{Type: "*fx_test.A"}},
+			Outputs:      []GraphOutput{{Type: "*fx_test.A"}},
+		}},
+		Edges: []GraphEdge{
+			{Consumer: b, Producer: a, Input: b.Inputs[0]},
+			{Consumer: c, Producer: b, Input: c.Inputs[0]},
+		},
+	}
+}
+
+func TestGraphFilter(t *testing.T) {
+	t.Parallel()
+
+	g := newExportGraph()
+
+	t.Run("module", func(t *testing.T) {
+		t.Parallel()
+
+		f := g.Filter(GraphModuleFilter("outer"))
+		assert.Equal(t, g.Constructors[1:], f.Constructors)
+		assert.Empty(t, f.Decorators)
+		assert.Equal(t, g.Edges[1:], f.Edges)
+	})
+
+	t.Run("type", func(t *testing.T) {
+		t.Parallel()
+
+		f := g.Filter(GraphTypeFilter("fx_test.A"))
+		assert.Equal(t, g.Constructors[:1], f.Constructors)
+		assert.Equal(t, g.Decorators, f.Decorators)
+		assert.Empty(t, f.Edges)
+	})
+
+	t.Run("all filters must match", func(t *testing.T) {
+		t.Parallel()
+
+		f := g.Filter(GraphModuleFilter("inner"), GraphTypeFilter("fx_test.C"))
+		assert.Empty(t, f.Constructors)
+
+		f = g.Filter(GraphModuleFilter("outer"), GraphTypeFilter("fx_test.C"))
+		assert.Equal(t, g.Constructors[2:], f.Constructors)
+	})
+
+	t.Run("app graph", func(t *testing.T) {
+		t.Parallel()
+
+		type A struct{}
+		app := fxtest.New(t, Module("mod", Provide(func() *A { return &A{} })))
+		f := app.Graph().Filter(GraphTypeFilter("fx."))
+		for _, c := range f.Constructors {
+			assert.Empty(t, c.ModulePath)
+		}
+		assert.NotEmpty(t, f.Constructors)
+		assert.Len(t, app.Graph().Filter(GraphModuleFilter("mod")).Constructors, 1)
+	})
+}
+
+func TestGraphJSON(t *testing.T) {
+	t.Parallel()
+
+	b, err := json.Marshal(newExportGraph())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"module": {
+			"constructors": [
+				{"id": 0, "supply": true, "inputs": [], "outputs": [{"type": "*fx_test.A"}], "invoked": true}
+			],
+			"decorators": [],
+			"modules": [
+				{
+					"name": "outer",
+					"constructors": [
+						{
+							"id": 2, "function": "fx_test.NewC",
+							"inputs": [{"type": "*fx_test.B", "optional": true}],
+							"outputs": [{"type": "*fx_test.C", "group": "cs"}],
+							"invoked": false
+						}
+					],
+					"decorators": [],
+					"modules": [
+						{
+							"name": "inner",
+							"constructors": [
+								{
+									"id": 1, "function": "fx_test.NewB", "private": true,
+									"inputs": [{"type": "*fx_test.A", "name": "a"}],
+									"outputs": [{"type": "*fx_test.B"}],
+									"invoked": true
+								}
+							],
+							"decorators": [],
+							"modules": []
+						}
+					]
+				},
+				{
+					"name": "other",
+					"constructors": [],
+					"decorators": [
+						{
+							"id": 0, "function": "fx_test.DecorateA",
+							"inputs": [{"type": "*fx_test.A"}],
+							"outputs": [{"type": "*fx_test.A"}]
+						}
+					],
+					"modules": []
+				}
+			]
+		},
+		"edges": [
+			{"consumer": 1, "producer": 0, "input": {"type": "*fx_test.A", "name": "a"}},
+			{"consumer": 2, "producer": 1, "input": {"type": "*fx_test.B", "optional": true}}
+		]
+	}`, string(b))
+
+	t.Run("app graph", func(t *testing.T) {
+		t.Parallel()
+
+		app := fxtest.New(t)
+		_, err := json.Marshal(app.Graph())
+		assert.NoError(t, err)
+	})
+}
+
+func TestGraphMermaid(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `flowchart LR
+	c0["*fx_test.A"]
+	subgraph m0 ["outer"]
+		c2["*fx_test.C[group = #quot;cs#quot;]"]
+		subgraph m1 ["inner"]
+			c1["*fx_test.B (private)"]
+		end
+	end
+	subgraph m2 ["other"]
+		d0{{"*fx_test.A"}}
+	end
+	c0 -->|"name: a"| c1
+	c1 -.-> c2
+`, newExportGraph().Mermaid())
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+	type unused struct{}
+
+	findConstructor := func(t *testing.T, g *Graph, typ string) *GraphConstructor {
+		for _, c := range g.Constructors {
+			for _, out := range c.Outputs {
+				if out.Type == typ {
+					return c
+				}
+			}
+		}
+		require.Failf(t, "constructor not found", "no constructor produces %v", typ)
+		return nil
+	}
+
+	t.Run("constructors", func(t *testing.T) {
+		t.Parallel()
+
+		newB := func(*A) *B { return &B{} }
+		app := fxtest.New(t,
+			Supply(&A{}),
+			Module("outer",
+				Module("inner",
+					Provide(newB, Private),
+					Invoke(func(*B) {}),
+				),
+				Provide(func() *unused { return &unused{} }),
+			),
+		)
+		g := app.Graph()
+
+		a := findConstructor(t, g, "*fx_test.A")
+		assert.True(t, a.Supply)
+		assert.Empty(t, a.FunctionName)
+		assert.Empty(t, a.ModulePath)
+		assert.True(t, a.Invoked)
+
+		b := findConstructor(t, g, "*fx_test.B")
+		assert.Contains(t, b.FunctionName, "TestGraph")
+		assert.Equal(t, []string{"outer", "inner"}, b.ModulePath)
+		assert.True(t, b.Private)
+		assert.False(t, b.Supply)
+		assert.True(t, b.Invoked)
+		assert.Contains(t, b.CallerName, "TestGraph")
+		assert.Contains(t, b.CallerFile, "graph_test.go")
+		assert.NotZero(t, b.CallerLine)
+		assert.Equal(t, []GraphInput{{Type: "*fx_test.A"}}, b.Inputs)
+		assert.Equal(t, []GraphOutput{{Type: "*fx_test.B"}}, b.Outputs)
+
+		u := findConstructor(t, g, "*fx_test.unused")
+		assert.Equal(t, []string{"outer"}, u.ModulePath)
+		assert.False(t, u.Invoked, "unused constructors must not run")
+
+		assert.Contains(t, g.Edges, GraphEdge{
+			Consumer: b,
+			Producer: a,
+			Input:    GraphInput{Type: "*fx_test.A"},
+		})
+
+		lc := findConstructor(t, g, "fx.Lifecycle")
+		assert.Contains(t, lc.CallerName, "go.uber.org/fx.New")
+	})
+
+	t.Run("tags", func(t *testing.T) {
+		t.Parallel()
+
+		type params struct {
+			In
+
+			Names    map[string]int `name:"names" optional:"true"`
+			Handlers []string       `group:"handlers"`
+		}
+		type results struct {
+			Out
+
+			Names   map[string]int `name:"names"`
+			Handler string         `group:"handlers"`
+		}
+		app := fxtest.New(t,
+			Provide(
+				func() results { return results{} },
+				func(params) *A { return &A{} },
+			),
+			Invoke(func(*A) {}),
+		)
+		g := app.Graph()
+
+		r := findConstructor(t, g, "map[string]int")
+		assert.Equal(t, []GraphOutput{
+			{Type: "map[string]int", Name: "names"},
+			{Type: "string", Group: "handlers"},
+		}, r.Outputs)
+
+		a := findConstructor(t, g, "*fx_test.A")
+		assert.Equal(t, []GraphInput{
+			{Type: "map[string]int", Name: "names", Optional: true},
+			{Type: "[]string", Group: "handlers"},
+		}, a.Inputs)
+
+		var edges []GraphInput
+		for _, e := range g.Edges {
+			if e.Consumer == a {
+				assert.Same(t, r, e.Producer)
+				edges = append(edges, e.Input)
+			}
+		}
+		assert.Equal(t, a.Inputs, edges)
+	})
+
+	t.Run("private edges", func(t *testing.T) {
+		t.Parallel()
+
+		type Config struct{}
+		module := func(name string) Option {
+			return Module(name,
+				Provide(
+					Private,
+					func() *Config { return &Config{} },
+				),
+				Provide(
+					Private,
+					func(*Config) *A { return &A{} },
+				),
+			)
+		}
+		app := fxtest.New(t, module("a"), module("b"))
+		g := app.Graph()
+
+		var edges []GraphEdge
+		for _, e := range g.Edges {
+			if e.Input.Type == "*fx_test.Config" {
+				edges = append(edges, e)
+			}
+		}
+		require.Len(t, edges, 2)
+		for _, e := range edges {
+			assert.Equal(t, e.Consumer.ModulePath, e.Producer.ModulePath,
+				"consumer must only see the Config of its own module")
+		}
+	})
+
+	t.Run("decorators", func(t *testing.T) {
+		t.Parallel()
+
+		app := fxtest.New(t,
+			Supply(&A{}),
+			Module("child",
+				Decorate(func(a *A) *A { return a }),
+				Replace(&B{}),
+			),
+		)
+		g := app.Graph()
+
+		require.Len(t, g.Decorators, 2)
+		d := g.Decorators[0]
+		assert.Contains(t, d.FunctionName, "TestGraph")
+		assert.Equal(t, []string{"child"}, d.ModulePath)
+		assert.False(t, d.Replace)
+		assert.Contains(t, d.CallerFile, "graph_test.go")
+		assert.Equal(t, []GraphInput{{Type: "*fx_test.A"}}, d.Inputs)
+		assert.Equal(t, []GraphOutput{{Type: "*fx_test.A"}}, d.Outputs)
+
+		r := g.Decorators[1]
+		assert.Empty(t, r.FunctionName)
+		assert.True(t, r.Replace)
+		assert.Equal(t, []GraphOutput{{Type: "*fx_test.B"}}, r.Outputs)
+	})
+
+	t.Run("injected", func(t *testing.T) {
+		t.Parallel()
+
+		var g *Graph
+		fxtest.New(t,
+			Supply(&A{}),
+			Invoke(func(i Introspector, _ *A) { g = i.Graph() }),
+		)
+		a := findConstructor(t, g, "*fx_test.A")
+		assert.True(t, a.Invoked)
+	})
+}
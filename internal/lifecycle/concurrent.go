@@ -0,0 +1,182 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package lifecycle
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/multierr"
+)
+
+// hookDeps returns, for each of the given hooks, the indexes of the earlier
+// hooks that it must not run before.
+func hookDeps(hooks []Hook) [][]int {
+	deps := make([][]int, len(hooks))
+	for i, hook := range hooks {
+		for j, dep := range hooks[:i] {
+			if hook.Owner == nil || dep.Owner == nil ||
+				hook.Owner == dep.Owner || hook.Owner.DependsOn(dep.Owner) {
+				deps[i] = append(deps[i], j)
+			}
+		}
+	}
+	return deps
+}
+
+// startConcurrently runs the OnStart hooks of the given hooks, starting
+// each hook as soon as the hooks it depends on have started. After the first
+// failure, no new hooks are started, but hooks that are already running are
+// allowed to finish so that they can be stopped.
+func (l *Lifecycle) startConcurrently(ctx context.Context, hooks []Hook) error {
+	l.mu.Lock()
+	l.started = make([]bool, len(hooks))
+	l.mu.Unlock()
+
+	var (
+		deps = hookDeps(hooks)
+		done = make([]chan struct{}, len(hooks))
+
+		abort     = make(chan struct{})
+		abortOnce sync.Once
+		startErr  error
+		wg        sync.WaitGroup
+	)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	fail := func(err error) {
+		abortOnce.Do(func() {
+			startErr = err
+			close(abort)
+		})
+	}
+
+	for i, hook := range hooks {
+		wg.Add(1)
+		go func(i int, hook Hook) {
+			defer wg.Done()
+
+			for _, d := range deps[i] {
+				select {
+				case <-done[d]:
+				case <-abort:
+					return
+				}
+			}
+			select {
+			case <-abort:
+				return
+			default:
+			}
+
+			// if ctx has cancelled, don't start any more hooks.
+			if err := ctx.Err(); err != nil {
+				fail(err)
+				return
+			}
+
+			if hook.OnStart != nil {
+				err := l.runStartHook(ctx, hook)
+				if err != nil {
+					fail(err)
+					return
+				}
+			}
+
+			l.mu.Lock()
+			l.started[i] = true
+			l.mu.Unlock()
+			close(done[i])
+		}(i, hook)
+	}
+
+	wg.Wait()
+	return startErr
+}
+
+// stopConcurrently runs the OnStop hooks of all the given hooks that were
+// started, running each hook only after the hooks that depend on it have
+// stopped.
+func (l *Lifecycle) stopConcurrently(ctx context.Context, hooks []Hook) error {
+	l.mu.Lock()
+	hooks = hooks[:len(l.started)]
+	started := l.started
+	l.started = nil
+	l.mu.Unlock()
+
+	dependents := make([][]int, len(hooks))
+	for i, deps := range hookDeps(hooks) {
+		for _, d := range deps {
+			dependents[d] = append(dependents[d], i)
+		}
+	}
+
+	var (
+		done = make([]chan struct{}, len(hooks))
+
+		errMu  sync.Mutex
+		errs   []error
+		ctxErr error
+		wg     sync.WaitGroup
+	)
+	for i := range done {
+		done[i] = make(chan struct{})
+	}
+
+	for i, hook := range hooks {
+		wg.Add(1)
+		go func(i int, hook Hook) {
+			defer wg.Done()
+			defer close(done[i])
+
+			for _, d := range dependents[i] {
+				<-done[d]
+			}
+
+			if !started[i] || hook.OnStop == nil {
+				return
+			}
+
+			if err := ctx.Err(); err != nil && l.stopGrace <= 0 {
+				errMu.Lock()
+				ctxErr = err
+				errMu.Unlock()
+				return
+			}
+
+			err := l.runStopHook(ctx, hook)
+			if err != nil {
+				// For best-effort cleanup, keep going after errors.
+				errMu.Lock()
+				errs = append(errs, err)
+				errMu.Unlock()
+			}
+		}(i, hook)
+	}
+
+	wg.Wait()
+	if ctxErr != nil {
+		return ctxErr
+	}
+	return multierr.Combine(errs...)
+}
@@ -114,36 +114,86 @@ func Wrap[T Callable](x T) (ContextErrorFunc, string) {
 
 // A Hook is a pair of start and stop callbacks, either of which can be nil,
 // plus a string identifying the supplier of the hook.
+//
+// A Hook may also have ready and drain callbacks. OnReady runs after the
+// OnStart callbacks of all hooks have succeeded, and OnDrain runs before
+// any OnStop callback.
+//
+// OnReload runs only when the started Lifecycle is asked to reload.
 type Hook struct {
-	OnStart     func(context.Context) error
-	OnStop      func(context.Context) error
-	OnStartName string
-	OnStopName  string
+	OnStart      func(context.Context) error
+	OnStop       func(context.Context) error
+	OnReady      func(context.Context) error
+	OnDrain      func(context.Context) error
+	OnReload     func(context.Context) error
+	OnStartName  string
+	OnStopName   string
+	OnReadyName  string
+	OnDrainName  string
+	OnReloadName string
+
+	// Timeout, if positive, bounds how long each of the hook's callbacks
+	// may run.
+	Timeout time.Duration
+
+	// StartRetry, if set, is consulted when OnStart fails. It receives the
+	// number of attempts made so far and reports how long to wait before
+	// trying again, or false if OnStart should not be retried.
+	StartRetry func(attempt int) (backoff time.Duration, retry bool)
+
+	// Owner is the component that appended this hook, if known. It is only
+	// used when the Lifecycle runs hooks concurrently.
+	Owner HookOwner
+
+	// Module is the name of the module that appended this hook, if any.
+	Module string
 
 	callerFrame fxreflect.Frame
 }
 
-type appState int
+// HookOwner identifies the component that appended a Hook to a Lifecycle.
+//
+// When a Lifecycle runs hooks concurrently, a hook waits only for the hooks
+// of owners that its own owner depends on. Hooks without an owner wait for
+// all hooks appended before them, and all hooks appended after them wait
+// for them.
+type HookOwner interface {
+	// DependsOn reports whether this owner depends on the given owner,
+	// directly or transitively.
+	DependsOn(HookOwner) bool
+}
+
+// State is the state of a Lifecycle.
+type State int
 
 const (
-	stopped appState = iota
-	starting
-	incompleteStart
-	started
-	stopping
+	// Stopped is the state of a Lifecycle that hasn't been started, or that
+	// has finished stopping.
+	Stopped State = iota
+	// Starting is the state of a Lifecycle while its OnStart and OnReady
+	// hooks run.
+	Starting
+	// IncompleteStart is the state of a Lifecycle that failed to start. Its
+	// started hooks must be stopped.
+	IncompleteStart
+	// Started is the state of a Lifecycle that started successfully.
+	Started
+	// Stopping is the state of a Lifecycle while its OnDrain and OnStop
+	// hooks run.
+	Stopping
 )
 
-func (as appState) String() string {
-	switch as {
-	case stopped:
+func (s State) String() string {
+	switch s {
+	case Stopped:
 		return "stopped"
-	case starting:
+	case Starting:
 		return "starting"
-	case incompleteStart:
+	case IncompleteStart:
 		return "incompleteStart"
-	case started:
+	case Started:
 		return "started"
-	case stopping:
+	case Stopping:
 		return "stopping"
 	default:
 		return "invalidState"
@@ -154,13 +204,38 @@ func (as appState) String() string {
 type Lifecycle struct {
 	clock        fxclock.Clock
 	logger       fxevent.Logger
-	state        appState
+	state        State
 	hooks        []Hook
 	numStarted   int
+	numReady     int
 	startRecords HookRecords
 	stopRecords  HookRecords
-	runningHook  Hook
+	startRuntime time.Duration
+	stopRuntime  time.Duration
+	running      []*runningHook // oldest first
+	begin        time.Time      // when the current or last Start, Stop or Reload began
 	mu           sync.Mutex
+
+	// Set if hooks should run concurrently in dependency order. In that
+	// case, started[i] reports whether hooks[i] was started successfully.
+	concurrent bool
+	started    []bool
+
+	// If positive, OnStart and OnStop hooks that run longer than this are
+	// reported periodically until they finish.
+	slowThreshold time.Duration
+
+	// Whether to record the stack of hooks that time out.
+	dumpOnTimeout bool
+
+	// If positive, Stop keeps calling OnStop hooks after its context ends,
+	// giving each hook this long to finish.
+	stopGrace time.Duration
+
+	// Held while OnReload hooks run so that reloads don't overlap, and
+	// by Stop while it leaves the Started state so that it doesn't run
+	// OnStop hooks during a reload.
+	reloadMu sync.Mutex
 }
 
 // New constructs a new Lifecycle.
@@ -168,12 +243,64 @@ func New(logger fxevent.Logger, clock fxclock.Clock) *Lifecycle {
 	return &Lifecycle{logger: logger, clock: clock}
 }
 
-// Append adds a Hook to the lifecycle.
+// RunConcurrently configures the Lifecycle to run independent hooks
+// concurrently. OnStart hooks still run only after the OnStart hooks of the
+// owners they depend on have succeeded, and OnStop hooks run only after the
+// OnStop hooks of the owners that depend on them have finished.
+func (l *Lifecycle) RunConcurrently() {
+	l.concurrent = true
+}
+
+// WarnSlowHooks configures the Lifecycle to emit an OnStartSlow or
+// OnStopSlow event every threshold while an OnStart or OnStop hook is
+// running.
+func (l *Lifecycle) WarnSlowHooks(threshold time.Duration) {
+	l.slowThreshold = threshold
+}
+
+// DumpGoroutinesOnTimeout configures the Lifecycle to record the stack of
+// the goroutine running a hook that times out. The stack is included in the
+// HookTimeoutError and logged as a GoroutineDump event.
+func (l *Lifecycle) DumpGoroutinesOnTimeout() {
+	l.dumpOnTimeout = true
+}
+
+// ContinueAfterDeadline configures Stop to keep calling the remaining OnStop
+// hooks after its context ends instead of returning immediately. Each of
+// those hooks runs with a fresh context that expires after grace, and Stop
+// stops waiting for it once that context ends. OnDrain hooks that have not
+// run by the deadline are skipped.
+func (l *Lifecycle) ContinueAfterDeadline(grace time.Duration) {
+	l.stopGrace = grace
+}
+
+// State returns the current state of the Lifecycle.
+func (l *Lifecycle) State() State {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.state
+}
+
+// stateChanged reports a transition between two states. It must be called
+// without holding l.mu so that loggers may inspect the Lifecycle.
+func (l *Lifecycle) stateChanged(from, to State) {
+	l.logger.LogEvent(&fxevent.StateChanged{
+		From: from.String(),
+		To:   to.String(),
+	})
+}
+
+// Append adds a Hook to the lifecycle. It's safe to call from running
+// hooks, but hooks appended while the lifecycle is starting or stopping
+// aren't run until the next Start.
 func (l *Lifecycle) Append(hook Hook) {
 	// Save the caller's stack frame to report file/line number.
 	if f := fxreflect.CallerStack(2, 0); len(f) > 0 {
 		hook.callerFrame = f[0]
 	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
 	l.hooks = append(l.hooks, hook)
 }
 
@@ -185,60 +312,126 @@ func (l *Lifecycle) Start(ctx context.Context) error {
 	}
 
 	l.mu.Lock()
-	if l.state != stopped {
+	if l.state != Stopped {
 		defer l.mu.Unlock()
 		return fmt.Errorf("attempted to start lifecycle when in state: %v", l.state)
 	}
 	l.numStarted = 0
-	l.state = starting
+	l.numReady = 0
+	l.state = Starting
 
-	l.startRecords = make(HookRecords, 0, len(l.hooks))
+	// Hooks appended while OnStart hooks run aren't started, so they
+	// mustn't get OnReady calls either.
+	hooks := l.hooks
+	l.startRecords = make(HookRecords, 0, len(hooks))
+	l.begin = l.clock.Now()
 	l.mu.Unlock()
+	l.stateChanged(Stopped, Starting)
 
-	var returnState appState = incompleteStart
+	returnState := IncompleteStart
 	defer func() {
 		l.mu.Lock()
 		l.state = returnState
+		l.startRuntime = l.clock.Since(l.begin)
 		l.mu.Unlock()
+		l.stateChanged(Starting, returnState)
 	}()
 
-	for _, hook := range l.hooks {
+	if l.concurrent {
+		if err := l.startConcurrently(ctx, hooks); err != nil {
+			return err
+		}
+	} else if err := l.startSequentially(ctx, hooks); err != nil {
+		return err
+	}
+
+	if err := l.ready(ctx, hooks); err != nil {
+		return err
+	}
+
+	returnState = Started
+	return nil
+}
+
+func (l *Lifecycle) startSequentially(ctx context.Context, hooks []Hook) error {
+	for _, hook := range hooks {
 		// if ctx has cancelled, bail out of the loop.
 		if err := ctx.Err(); err != nil {
 			return err
 		}
 
 		if hook.OnStart != nil {
-			l.mu.Lock()
-			l.runningHook = hook
-			l.mu.Unlock()
-
-			runtime, err := l.runStartHook(ctx, hook)
-			if err != nil {
+			if err := l.runStartHook(ctx, hook); err != nil {
 				return err
 			}
-
-			l.mu.Lock()
-			l.startRecords = append(l.startRecords, HookRecord{
-				CallerFrame: hook.callerFrame,
-				Func:        hook.OnStart,
-				Runtime:     runtime,
-			})
-			l.mu.Unlock()
 		}
 		l.numStarted++
 	}
+	return nil
+}
 
-	returnState = started
+// ready runs the OnReady hooks of the given hooks in the order they were
+// appended. It's called only after all of their OnStart hooks have
+// succeeded.
+func (l *Lifecycle) ready(ctx context.Context, hooks []Hook) error {
+	for _, hook := range hooks {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if hook.OnReady != nil {
+			if err := l.runReadyHook(ctx, hook); err != nil {
+				return err
+			}
+		}
+		l.numReady++
+	}
 	return nil
 }
 
-func (l *Lifecycle) runStartHook(ctx context.Context, hook Hook) (runtime time.Duration, err error) {
+func (l *Lifecycle) runReadyHook(ctx context.Context, hook Hook) (err error) {
+	funcName := hook.OnReadyName
+	if len(funcName) == 0 {
+		funcName = fxreflect.FuncName(hook.OnReady)
+	}
+
+	var (
+		begin   time.Time
+		runtime time.Duration
+	)
+
+	l.logger.LogEvent(&fxevent.OnReadyExecuting{
+		CallerName:   hook.callerFrame.Function,
+		FunctionName: funcName,
+	})
+	defer func() {
+		l.logger.LogEvent(&fxevent.OnReadyExecuted{
+			CallerName:   hook.callerFrame.Function,
+			FunctionName: funcName,
+			Runtime:      runtime,
+			Timeout:      hook.Timeout,
+			Err:          err,
+		})
+		l.record(&l.startRecords, hook, "OnReady", hook.OnReady, funcName, begin, runtime, err)
+	}()
+
+	begin = l.clock.Now()
+	err = l.callHook(ctx, hook, hook.OnReady, "OnReady", funcName)
+	runtime = l.clock.Since(begin)
+	return err
+}
+
+func (l *Lifecycle) runStartHook(ctx context.Context, hook Hook) (err error) {
 	funcName := hook.OnStartName
 	if len(funcName) == 0 {
 		funcName = fxreflect.FuncName(hook.OnStart)
 	}
 
+	var (
+		begin   time.Time
+		runtime time.Duration
+	)
+
 	l.logger.LogEvent(&fxevent.OnStartExecuting{
 		CallerName:   hook.callerFrame.Function,
 		FunctionName: funcName,
@@ -248,79 +441,265 @@ func (l *Lifecycle) runStartHook(ctx context.Context, hook Hook) (runtime time.D
 			CallerName:   hook.callerFrame.Function,
 			FunctionName: funcName,
 			Runtime:      runtime,
+			Timeout:      hook.Timeout,
 			Err:          err,
 		})
+		l.record(&l.startRecords, hook, "OnStart", hook.OnStart, funcName, begin, runtime, err)
 	}()
 
-	begin := l.clock.Now()
-	err = hook.OnStart(ctx)
-	return l.clock.Since(begin), err
+	begin = l.clock.Now()
+	defer l.watchSlow(func(elapsed time.Duration) fxevent.Event {
+		return &fxevent.OnStartSlow{
+			CallerName:   hook.callerFrame.Function,
+			FunctionName: funcName,
+			Elapsed:      elapsed,
+		}
+	})()
+
+	for attempt := 1; ; attempt++ {
+		err = l.callHook(ctx, hook, hook.OnStart, "OnStart", funcName)
+		if err == nil || hook.StartRetry == nil {
+			break
+		}
+
+		backoff, retry := hook.StartRetry(attempt)
+		if !retry || !l.canWait(ctx, backoff) {
+			break
+		}
+
+		l.logger.LogEvent(&fxevent.OnStartRetrying{
+			CallerName:   hook.callerFrame.Function,
+			FunctionName: funcName,
+			Attempt:      attempt,
+			Backoff:      backoff,
+			Err:          err,
+		})
+		if !l.wait(ctx, backoff) {
+			break
+		}
+	}
+	runtime = l.clock.Since(begin)
+	return err
+}
+
+// canWait reports whether waiting for d would leave the given context with
+// time remaining before its deadline.
+func (l *Lifecycle) canWait(ctx context.Context, d time.Duration) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	deadline, ok := ctx.Deadline()
+	return !ok || l.clock.Now().Add(d).Before(deadline)
+}
+
+// wait blocks for d, returning false if ctx ended first.
+func (l *Lifecycle) wait(ctx context.Context, d time.Duration) bool {
+	waitCtx, cancel := l.clock.WithTimeout(ctx, d)
+	defer cancel()
+
+	<-waitCtx.Done()
+	return ctx.Err() == nil
 }
 
-// Stop runs any OnStop hooks whose OnStart counterpart succeeded. OnStop
-// hooks run in reverse order.
+// Stop runs any OnDrain hooks whose OnReady counterpart succeeded, followed
+// by any OnStop hooks whose OnStart counterpart succeeded. Both run in
+// reverse order.
 func (l *Lifecycle) Stop(ctx context.Context) error {
 	if ctx == nil {
 		return errors.New("called OnStop with nil context")
 	}
 
+	// Wait for any reload in progress so that OnStop hooks don't run
+	// alongside OnReload hooks. Reloads that begin after this see that
+	// the Lifecycle is no longer started.
+	l.reloadMu.Lock()
 	l.mu.Lock()
-	if l.state != started && l.state != incompleteStart {
-		defer l.mu.Unlock()
+	prevState := l.state
+	if prevState != Started && prevState != IncompleteStart {
+		l.mu.Unlock()
+		l.reloadMu.Unlock()
 		return nil
 	}
-	l.state = stopping
+	l.state = Stopping
+	l.begin = l.clock.Now()
 	l.mu.Unlock()
+	l.reloadMu.Unlock()
+	l.stateChanged(prevState, Stopping)
 
 	defer func() {
 		l.mu.Lock()
-		l.state = stopped
+		l.state = Stopped
+		l.stopRuntime = l.clock.Since(l.begin)
 		l.mu.Unlock()
+		l.stateChanged(Stopping, Stopped)
 	}()
 
 	l.mu.Lock()
 	l.stopRecords = make(HookRecords, 0, l.numStarted)
+	hooks := l.hooks
 	l.mu.Unlock()
 
+	errs, err := l.drain(ctx, hooks)
+	if err != nil {
+		return err
+	}
+
+	if l.concurrent {
+		return multierr.Append(multierr.Combine(errs...), l.stopConcurrently(ctx, hooks))
+	}
+
 	// Run backward from last successful OnStart.
-	var errs []error
 	for ; l.numStarted > 0; l.numStarted-- {
-		if err := ctx.Err(); err != nil {
+		if err := ctx.Err(); err != nil && l.stopGrace <= 0 {
 			return err
 		}
-		hook := l.hooks[l.numStarted-1]
+		hook := hooks[l.numStarted-1]
 		if hook.OnStop == nil {
 			continue
 		}
 
-		l.mu.Lock()
-		l.runningHook = hook
-		l.mu.Unlock()
-
-		runtime, err := l.runStopHook(ctx, hook)
+		err := l.runStopHook(ctx, hook)
 		if err != nil {
 			// For best-effort cleanup, keep going after errors.
 			errs = append(errs, err)
 		}
+	}
 
-		l.mu.Lock()
-		l.stopRecords = append(l.stopRecords, HookRecord{
-			CallerFrame: hook.callerFrame,
-			Func:        hook.OnStop,
-			Runtime:     runtime,
-		})
-		l.mu.Unlock()
+	return multierr.Combine(errs...)
+}
+
+// HasReloadHooks reports whether any of the appended hooks has an OnReload
+// callback.
+func (l *Lifecycle) HasReloadHooks() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, hook := range l.hooks {
+		if hook.OnReload != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Reload runs all OnReload hooks in the order they were appended. Unlike
+// Start, it keeps going after a hook fails and returns the errors of all
+// failed hooks, but it stops once ctx ends. Reload never changes the state
+// of the Lifecycle, which must be started. Concurrent calls to Reload run
+// one after the other.
+func (l *Lifecycle) Reload(ctx context.Context) error {
+	if ctx == nil {
+		return errors.New("called OnReload with nil context")
 	}
 
+	l.reloadMu.Lock()
+	defer l.reloadMu.Unlock()
+
+	l.mu.Lock()
+	if l.state != Started {
+		defer l.mu.Unlock()
+		return fmt.Errorf("attempted to reload lifecycle when in state: %v", l.state)
+	}
+	hooks := l.hooks
+	l.begin = l.clock.Now()
+	l.mu.Unlock()
+
+	var errs []error
+	for _, hook := range hooks {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if hook.OnReload == nil {
+			continue
+		}
+
+		funcName := hook.OnReloadName
+		if len(funcName) == 0 {
+			funcName = fxreflect.FuncName(hook.OnReload)
+		}
+		if err := l.callHook(ctx, hook, hook.OnReload, "OnReload", funcName); err != nil {
+			errs = append(errs, err)
+		}
+	}
 	return multierr.Combine(errs...)
 }
 
-func (l *Lifecycle) runStopHook(ctx context.Context, hook Hook) (runtime time.Duration, err error) {
+// drain runs OnDrain hooks backward from the last successful OnReady. It
+// returns the errors reported by the hooks, or ctx.Err() if the context
+// ended before all hooks ran.
+//
+// If Stop continues after its deadline, hooks that have not run by then are
+// skipped and reported among the returned errors instead: there's no time
+// left to drain, but OnStop hooks still need to release resources.
+func (l *Lifecycle) drain(ctx context.Context, hooks []Hook) (errs []error, err error) {
+	for ; l.numReady > 0; l.numReady-- {
+		hook := hooks[l.numReady-1]
+		if err := ctx.Err(); err != nil {
+			if l.stopGrace <= 0 {
+				return nil, err
+			}
+			if hook.OnDrain != nil {
+				errs = append(errs, drainSkippedError(hook, err))
+			}
+			continue
+		}
+		if hook.OnDrain == nil {
+			continue
+		}
+
+		err := l.runDrainHook(ctx, hook)
+		if err != nil {
+			// Keep draining; OnStop hooks still need to run.
+			errs = append(errs, err)
+		}
+	}
+	return errs, nil
+}
+
+func (l *Lifecycle) runDrainHook(ctx context.Context, hook Hook) (err error) {
+	funcName := hook.OnDrainName
+	if len(funcName) == 0 {
+		funcName = fxreflect.FuncName(hook.OnDrain)
+	}
+
+	var (
+		begin   time.Time
+		runtime time.Duration
+	)
+
+	l.logger.LogEvent(&fxevent.OnDrainExecuting{
+		CallerName:   hook.callerFrame.Function,
+		FunctionName: funcName,
+	})
+	defer func() {
+		l.logger.LogEvent(&fxevent.OnDrainExecuted{
+			CallerName:   hook.callerFrame.Function,
+			FunctionName: funcName,
+			Runtime:      runtime,
+			Timeout:      hook.Timeout,
+			Err:          err,
+		})
+		l.record(&l.stopRecords, hook, "OnDrain", hook.OnDrain, funcName, begin, runtime, err)
+	}()
+
+	begin = l.clock.Now()
+	err = l.callStopHook(ctx, hook, hook.OnDrain, "OnDrain", funcName)
+	runtime = l.clock.Since(begin)
+	return err
+}
+
+func (l *Lifecycle) runStopHook(ctx context.Context, hook Hook) (err error) {
 	funcName := hook.OnStopName
 	if len(funcName) == 0 {
 		funcName = fxreflect.FuncName(hook.OnStop)
 	}
 
+	var (
+		begin   time.Time
+		runtime time.Duration
+	)
+
 	l.logger.LogEvent(&fxevent.OnStopExecuting{
 		CallerName:   hook.callerFrame.Function,
 		FunctionName: funcName,
@@ -330,28 +709,394 @@ func (l *Lifecycle) runStopHook(ctx context.Context, hook Hook) (runtime time.Du
 			CallerName:   hook.callerFrame.Function,
 			FunctionName: funcName,
 			Runtime:      runtime,
+			Timeout:      hook.Timeout,
 			Err:          err,
 		})
+		l.record(&l.stopRecords, hook, "OnStop", hook.OnStop, funcName, begin, runtime, err)
 	}()
 
+	begin = l.clock.Now()
+	stopWatching := l.watchSlow(func(elapsed time.Duration) fxevent.Event {
+		return &fxevent.OnStopSlow{
+			CallerName:   hook.callerFrame.Function,
+			FunctionName: funcName,
+			Elapsed:      elapsed,
+		}
+	})
+	err = l.callStopHook(ctx, hook, hook.OnStop, "OnStop", funcName)
+	stopWatching()
+	runtime = l.clock.Since(begin)
+	return err
+}
+
+// watchSlow logs the event built by newEvent every slowThreshold until the
+// returned function is called. The event is given the time elapsed since
+// watchSlow was called.
+func (l *Lifecycle) watchSlow(newEvent func(elapsed time.Duration) fxevent.Event) (stop func()) {
+	if l.slowThreshold <= 0 {
+		return func() {}
+	}
+
 	begin := l.clock.Now()
-	err = hook.OnStop(ctx)
-	return l.clock.Since(begin), err
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			waitCtx, waitCancel := l.clock.WithTimeout(ctx, l.slowThreshold)
+			<-waitCtx.Done()
+			waitCancel()
+			if ctx.Err() != nil {
+				return
+			}
+			l.logger.LogEvent(newEvent(l.clock.Since(begin)))
+		}
+	}()
+
+	// Wait for the watcher to exit so that no warning is logged after the
+	// hook has finished.
+	return func() {
+		cancel()
+		<-done
+	}
+}
+
+// callHook calls fn, which is one of the hook's callbacks, enforcing the
+// hook's timeout if it has one.
+func (l *Lifecycle) callHook(
+	ctx context.Context,
+	hook Hook,
+	fn func(context.Context) error,
+	method, funcName string,
+) error {
+	r := &runningHook{hook: hook, method: method, funcName: funcName}
+	l.mu.Lock()
+	l.running = append(l.running, r)
+	l.mu.Unlock()
+	defer func() {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, other := range l.running {
+			if other == r {
+				l.running = append(l.running[:i], l.running[i+1:]...)
+				break
+			}
+		}
+	}()
+
+	if hook.Timeout <= 0 {
+		l.trackGoroutine(r)
+		err := fn(ctx)
+		if err != nil && ctx.Err() == context.DeadlineExceeded {
+			// The caller's deadline expired while this hook ran.
+			err = l.timeoutError(r, l.remaining(ctx))
+		}
+		return err
+	}
+
+	hookCtx, cancel := l.clock.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	// Run the hook in a separate goroutine so that we stop waiting for it
+	// once its deadline passes, even if it doesn't respect its context.
+	c := make(chan error, 1)
+	go func() {
+		l.trackGoroutine(r)
+		c <- fn(hookCtx)
+	}()
+
+	var err error
+	select {
+	case err = <-c:
+	case <-hookCtx.Done():
+		err = hookCtx.Err()
+	}
+
+	if err != nil {
+		switch {
+		case ctx.Err() == nil && hookCtx.Err() == context.DeadlineExceeded:
+			// The hook's own deadline expired.
+			err = l.timeoutError(r, hook.Timeout)
+		case ctx.Err() == context.DeadlineExceeded:
+			// The caller's deadline expired while this hook ran.
+			err = l.timeoutError(r, l.remaining(ctx))
+		}
+	}
+	return err
+}
+
+// callStopHook calls fn, which is one of the hook's OnDrain or OnStop
+// callbacks. Unless Stop continues after its deadline, this is the same as
+// callHook.
+//
+// Otherwise, a hook that runs after the deadline gets a fresh context that
+// expires after the grace period. A hook without its own Timeout that is
+// still running when the deadline passes is given the grace period to
+// return before we stop waiting for it.
+func (l *Lifecycle) callStopHook(
+	ctx context.Context,
+	hook Hook,
+	fn func(context.Context) error,
+	method, funcName string,
+) error {
+	if l.stopGrace <= 0 {
+		return l.callHook(ctx, hook, fn, method, funcName)
+	}
+
+	if ctx.Err() != nil {
+		if hook.Timeout <= 0 || hook.Timeout > l.stopGrace {
+			hook.Timeout = l.stopGrace
+		}
+		return l.callHook(context.Background(), hook, fn, method, funcName)
+	}
+
+	c := make(chan error, 1)
+	go func() {
+		c <- l.callHook(ctx, hook, fn, method, funcName)
+	}()
+
+	select {
+	case err := <-c:
+		return err
+	case <-ctx.Done():
+	}
+
+	graceCtx, cancel := l.clock.WithTimeout(context.Background(), l.stopGrace)
+	defer cancel()
+
+	select {
+	case err := <-c:
+		return err
+	case <-graceCtx.Done():
+		r := &runningHook{hook: hook, method: method, funcName: funcName}
+		return l.timeoutError(r, l.remaining(ctx)+l.stopGrace)
+	}
 }
 
-// RunningHookCaller returns the name of the hook that was running when a Start/Stop
-// hook timed out.
+// drainSkippedError reports that the hook's OnDrain callback was not called
+// because the context passed to Stop ended first.
+func drainSkippedError(hook Hook, err error) error {
+	funcName := hook.OnDrainName
+	if len(funcName) == 0 {
+		funcName = fxreflect.FuncName(hook.OnDrain)
+	}
+	var module string
+	if len(hook.Module) > 0 {
+		module = fmt.Sprintf(" in module %q", hook.Module)
+	}
+	return fmt.Errorf("OnDrain hook %s appended by %s%s skipped: %w",
+		funcName, hook.callerFrame, module, err)
+}
+
+// runningHook describes a hook callback that is currently running.
+type runningHook struct {
+	hook     Hook
+	method   string
+	funcName string
+
+	// ID of the goroutine running the callback, if known. Guarded by the
+	// Lifecycle's mutex.
+	goroutine int64
+}
+
+// trackGoroutine records the current goroutine as the one running r, if
+// goroutines should be dumped on timeout.
+func (l *Lifecycle) trackGoroutine(r *runningHook) {
+	if !l.dumpOnTimeout {
+		return
+	}
+
+	id := goroutineID()
+	l.mu.Lock()
+	r.goroutine = id
+	l.mu.Unlock()
+}
+
+// remaining returns how long Start, Stop or Reload had between when it
+// began and the deadline of the given context.
+func (l *Lifecycle) remaining(ctx context.Context) time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline.Sub(l.begin)
+	}
+	return l.clock.Since(l.begin)
+}
+
+// TimeoutError returns a HookTimeoutError for the hook that is running when
+// the context passed to Start, Stop or Reload expires, or nil if no hook is
+// running. If several hooks are running concurrently, it reports the one
+// that has been running the longest.
+func (l *Lifecycle) TimeoutError(ctx context.Context) *HookTimeoutError {
+	r := l.oldestRunning()
+	if r == nil {
+		return nil
+	}
+	return l.timeoutError(r, l.remaining(ctx))
+}
+
+// oldestRunning returns the hook that has been running the longest, or nil
+// if no hook is running.
+func (l *Lifecycle) oldestRunning() *runningHook {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if len(l.running) == 0 {
+		return nil
+	}
+	return l.running[0]
+}
+
+func (l *Lifecycle) timeoutError(r *runningHook, timeout time.Duration) *HookTimeoutError {
+	err := &HookTimeoutError{
+		Method:       r.method,
+		FunctionName: r.funcName,
+		Caller:       r.hook.callerFrame.String(),
+		Module:       r.hook.Module,
+		Timeout:      timeout,
+	}
+
+	l.mu.Lock()
+	var records HookRecords
+	switch r.method {
+	case "OnStart", "OnReady":
+		records = l.startRecords
+	case "OnDrain", "OnStop":
+		records = l.stopRecords
+	}
+	for _, rec := range records {
+		if rec.Err == nil {
+			err.Completed = append(err.Completed, rec.FuncName)
+		}
+	}
+	goroutine := r.goroutine
+	l.mu.Unlock()
+
+	if goroutine != 0 {
+		err.Stack = goroutineStack(goroutine)
+		l.logger.LogEvent(&fxevent.GoroutineDump{
+			Method:       r.method,
+			FunctionName: r.funcName,
+			CallerName:   r.hook.callerFrame.Function,
+			Stack:        err.Stack,
+		})
+	}
+	return err
+}
+
+// HookTimeoutError is returned when a hook does not finish within the
+// timeout specified for it, or before the deadline of the context passed to
+// Start or Stop.
+type HookTimeoutError struct {
+	// Method is the kind of hook that timed out: one of "OnStart",
+	// "OnReady", "OnDrain", "OnStop" and "OnReload".
+	Method string
+
+	// FunctionName is the name of the hook function.
+	FunctionName string
+
+	// Caller identifies the function, file and line that appended the
+	// hook to the lifecycle.
+	Caller string
+
+	// Module is the name of the module that appended the hook, if any.
+	Module string
+
+	// Timeout is the timeout that the hook exceeded. If the hook ran past
+	// the deadline of the context passed to Start, Stop or Reload, this is
+	// the time that it had to finish.
+	Timeout time.Duration
+
+	// Completed lists the names of the hook functions that finished
+	// successfully before the timeout, in the order they finished. It's
+	// empty for OnReload hooks.
+	Completed []string
+
+	// Stack is the stack trace of the goroutine running the hook when it
+	// timed out. It's only recorded if requested.
+	Stack string
+}
+
+func (e *HookTimeoutError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s hook %s appended by %s", e.Method, e.FunctionName, e.Caller)
+	if len(e.Module) > 0 {
+		fmt.Fprintf(&b, " in module %q", e.Module)
+	}
+	fmt.Fprintf(&b, " timed out after %v: %v", e.Timeout, context.DeadlineExceeded)
+	return b.String()
+}
+
+// Unwrap returns context.DeadlineExceeded so that errors.Is can be used to
+// check for timeouts.
+func (e *HookTimeoutError) Unwrap() error {
+	return context.DeadlineExceeded
+}
+
+// RunningHookCaller returns the name of the function that appended the hook
+// that is currently running, if any. If several hooks are running
+// concurrently, it reports the one that has been running the longest.
 func (l *Lifecycle) RunningHookCaller() string {
+	r := l.oldestRunning()
+	if r == nil {
+		return ""
+	}
+	return r.hook.callerFrame.Function
+}
+
+// record appends a HookRecord for a hook callback that ran to rs.
+func (l *Lifecycle) record(
+	rs *HookRecords,
+	hook Hook,
+	method string,
+	fn func(context.Context) error,
+	funcName string,
+	begin time.Time,
+	runtime time.Duration,
+	err error,
+) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	*rs = append(*rs, HookRecord{
+		CallerFrame: hook.callerFrame,
+		Func:        fn,
+		Start:       begin,
+		Runtime:     runtime,
+		Method:      method,
+		FuncName:    funcName,
+		Module:      hook.Module,
+		Err:         err,
+	})
+}
+
+// StartRecords returns records of the hooks run by the most recent call to
+// Start, in the order they finished, and how long that call took.
+func (l *Lifecycle) StartRecords() (HookRecords, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return append(HookRecords(nil), l.startRecords...), l.startRuntime
+}
+
+// StopRecords returns records of the hooks run by the most recent call to
+// Stop, in the order they finished, and how long that call took.
+func (l *Lifecycle) StopRecords() (HookRecords, time.Duration) {
 	l.mu.Lock()
 	defer l.mu.Unlock()
-	return l.runningHook.callerFrame.Function
+	return append(HookRecords(nil), l.stopRecords...), l.stopRuntime
 }
 
 // HookRecord keeps track of each Hook's execution time, the caller that appended the Hook, and function that ran as the Hook.
 type HookRecord struct {
 	CallerFrame fxreflect.Frame             // stack frame of the caller
 	Func        func(context.Context) error // function that ran as sanitized name
+	Start       time.Time                   // when the hook started running
 	Runtime     time.Duration               // how long the hook ran
+	Method      string                      // kind of hook, e.g. "OnStart"
+	FuncName    string                      // name of the function that ran
+	Module      string                      // module that appended the hook
+	Err         error                       // error returned by the hook, if any
 }
 
 // HookRecords is a Stringer wrapper of HookRecord slice.
@@ -26,11 +26,13 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/atomic"
 	"go.uber.org/fx/fxevent"
 	"go.uber.org/fx/internal/fxclock"
 	"go.uber.org/fx/internal/fxlog"
@@ -333,6 +335,986 @@ func TestLifecycleStop(t *testing.T) {
 	})
 }
 
+func TestLifecycleSlowHooks(t *testing.T) {
+	t.Parallel()
+
+	const threshold = 5 * time.Millisecond
+
+	// waitFor returns a hook function that blocks until the spy has seen
+	// two events with the given type name.
+	waitFor := func(t *testing.T, spy *fxlog.Spy, name string) func(context.Context) error {
+		return func(context.Context) error {
+			assert.Eventually(t, func() bool {
+				return len(spy.Events().SelectByTypeName(name)) >= 2
+			}, time.Second, time.Millisecond)
+			return nil
+		}
+	}
+
+	t.Run("Start", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.WarnSlowHooks(threshold)
+		l.Append(Hook{OnStart: waitFor(t, spy, "OnStartSlow")})
+		require.NoError(t, l.Start(context.Background()))
+
+		slow := spy.Events().SelectByTypeName("OnStartSlow")
+		require.GreaterOrEqual(t, len(slow), 2)
+		first := slow[0].(*fxevent.OnStartSlow)
+		assert.GreaterOrEqual(t, first.Elapsed, threshold)
+		assert.Less(t, first.Elapsed, slow[1].(*fxevent.OnStartSlow).Elapsed)
+		assert.NotEmpty(t, first.FunctionName)
+
+		types := spy.EventTypes()
+		assert.Equal(t, []string{"OnStartExecuted", "StateChanged"}, types[len(types)-2:],
+			"no slow events may be logged after the hook finished")
+	})
+
+	t.Run("Stop", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.WarnSlowHooks(threshold)
+		l.Append(Hook{OnStop: waitFor(t, spy, "OnStopSlow")})
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+
+		assert.GreaterOrEqual(t, len(spy.Events().SelectByTypeName("OnStopSlow")), 2)
+		assert.Empty(t, spy.Events().SelectByTypeName("OnStartSlow"))
+	})
+
+	t.Run("FastHooks", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.WarnSlowHooks(time.Minute)
+		l.Append(Hook{
+			OnStart: func(context.Context) error { return nil },
+			OnStop:  func(context.Context) error { return nil },
+		})
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+
+		assert.Equal(t, []string{
+			"StateChanged",
+			"OnStartExecuting", "OnStartExecuted",
+			"StateChanged",
+			"StateChanged",
+			"OnStopExecuting", "OnStopExecuted",
+			"StateChanged",
+		}, spy.EventTypes())
+	})
+}
+
+func TestLifecycleReadyDrain(t *testing.T) {
+	t.Parallel()
+
+	// recorder returns a Hook that records each of its callbacks under the
+	// given name, failing the callbacks listed in fail.
+	type recorder struct {
+		mu    sync.Mutex
+		calls []string
+	}
+	hook := func(r *recorder, name string, fail ...string) Hook {
+		call := func(method string) func(context.Context) error {
+			return func(context.Context) error {
+				r.mu.Lock()
+				defer r.mu.Unlock()
+				r.calls = append(r.calls, method+" "+name)
+				for _, f := range fail {
+					if f == method {
+						return errors.New(method + " " + name + " failed")
+					}
+				}
+				return nil
+			}
+		}
+		return Hook{
+			OnStart: call("start"),
+			OnReady: call("ready"),
+			OnDrain: call("drain"),
+			OnStop:  call("stop"),
+		}
+	}
+
+	t.Run("Order", func(t *testing.T) {
+		t.Parallel()
+
+		var r recorder
+		l := New(testLogger(t), fxclock.System)
+		l.Append(hook(&r, "a"))
+		l.Append(Hook{OnStart: func(context.Context) error { return nil }})
+		l.Append(hook(&r, "b"))
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"start a", "start b",
+			"ready a", "ready b",
+			"drain b", "drain a",
+			"stop b", "stop a",
+		}, r.calls)
+	})
+
+	t.Run("ReadyErrRollsBack", func(t *testing.T) {
+		t.Parallel()
+
+		var r recorder
+		l := New(testLogger(t), fxclock.System)
+		l.Append(hook(&r, "a"))
+		l.Append(hook(&r, "b", "ready"))
+		l.Append(hook(&r, "c"))
+
+		assert.EqualError(t, l.Start(context.Background()), "ready b failed")
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"start a", "start b", "start c",
+			"ready a", "ready b",
+			"drain a",
+			"stop c", "stop b", "stop a",
+		}, r.calls)
+	})
+
+	t.Run("DrainErrDoesntHaltStop", func(t *testing.T) {
+		t.Parallel()
+
+		var r recorder
+		l := New(testLogger(t), fxclock.System)
+		l.Append(hook(&r, "a"))
+		l.Append(hook(&r, "b", "drain"))
+
+		require.NoError(t, l.Start(context.Background()))
+		assert.EqualError(t, l.Stop(context.Background()), "drain b failed")
+		assert.Equal(t, []string{
+			"start a", "start b",
+			"ready a", "ready b",
+			"drain b", "drain a",
+			"stop b", "stop a",
+		}, r.calls)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		var r recorder
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+		l.Append(hook(&r, "a"))
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{"start a", "ready a", "drain a", "stop a"}, r.calls)
+	})
+
+	t.Run("AppendedDuringStart", func(t *testing.T) {
+		t.Parallel()
+
+		for _, concurrent := range []bool{false, true} {
+			var r recorder
+			l := New(testLogger(t), fxclock.System)
+			if concurrent {
+				l.RunConcurrently()
+			}
+			a := hook(&r, "a")
+			onStart := a.OnStart
+			a.OnStart = func(ctx context.Context) error {
+				l.Append(hook(&r, "b"))
+				return onStart(ctx)
+			}
+			l.Append(a)
+
+			// b was appended after Start began, so none of its
+			// callbacks run until the next Start.
+			require.NoError(t, l.Start(context.Background()))
+			require.NoError(t, l.Stop(context.Background()))
+			assert.Equal(t, []string{"start a", "ready a", "drain a", "stop a"}, r.calls,
+				"concurrent: %v", concurrent)
+		}
+	})
+
+	t.Run("Events", func(t *testing.T) {
+		t.Parallel()
+
+		var r recorder
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.Append(hook(&r, "a"))
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"StateChanged",
+			"OnStartExecuting", "OnStartExecuted",
+			"OnReadyExecuting", "OnReadyExecuted",
+			"StateChanged",
+			"StateChanged",
+			"OnDrainExecuting", "OnDrainExecuted",
+			"OnStopExecuting", "OnStopExecuted",
+			"StateChanged",
+		}, spy.EventTypes())
+	})
+}
+
+func TestLifecycleReload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("NotStarted", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{OnReload: func(context.Context) error { return nil }})
+
+		err := l.Reload(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "attempted to reload lifecycle when in state: stopped")
+	})
+
+	t.Run("RunsAllHooksInOrder", func(t *testing.T) {
+		t.Parallel()
+
+		var reloaded []string
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{OnReload: func(context.Context) error {
+			reloaded = append(reloaded, "a")
+			return errors.New("a failed")
+		}})
+		l.Append(Hook{OnStart: func(context.Context) error { return nil }})
+		l.Append(Hook{OnReload: func(context.Context) error {
+			reloaded = append(reloaded, "b")
+			return errors.New("b failed")
+		}})
+		l.Append(Hook{OnReload: func(context.Context) error {
+			reloaded = append(reloaded, "c")
+			return nil
+		}})
+
+		require.NoError(t, l.Start(context.Background()))
+		assert.True(t, l.HasReloadHooks())
+
+		err := l.Reload(context.Background())
+		assert.EqualError(t, err, "a failed; b failed")
+		assert.Equal(t, []string{"a", "b", "c"}, reloaded)
+		assert.Equal(t, Started, l.State(), "reload must not change state")
+		require.NoError(t, l.Stop(context.Background()))
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{
+			OnStart: func(context.Context) error { return nil },
+		})
+		l.Append(Hook{
+			OnReload: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			OnReloadName: "slowReload",
+			Timeout:      time.Millisecond,
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, l.Reload(context.Background()), &timeoutErr)
+		assert.Equal(t, "OnReload", timeoutErr.Method)
+		assert.Equal(t, "slowReload", timeoutErr.FunctionName)
+		assert.Empty(t, timeoutErr.Completed)
+		require.NoError(t, l.Stop(context.Background()))
+	})
+
+	t.Run("StopWaitsForReload", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu     sync.Mutex
+			events []string
+		)
+		record := func(ev string) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, ev)
+		}
+
+		reloading := make(chan struct{})
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{
+			OnStart: func(context.Context) error { return nil },
+			OnStop: func(context.Context) error {
+				record("stop")
+				return nil
+			},
+			OnReload: func(context.Context) error {
+				close(reloading)
+				time.Sleep(50 * time.Millisecond)
+				record("reload")
+				return nil
+			},
+		})
+		require.NoError(t, l.Start(context.Background()))
+
+		reloadErr := make(chan error, 1)
+		go func() { reloadErr <- l.Reload(context.Background()) }()
+		<-reloading
+
+		require.NoError(t, l.Stop(context.Background()))
+		require.NoError(t, <-reloadErr)
+		assert.Equal(t, []string{"reload", "stop"}, events)
+	})
+
+	t.Run("NoReloadHooks", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{OnStart: func(context.Context) error { return nil }})
+		assert.False(t, l.HasReloadHooks())
+	})
+}
+
+func TestLifecycleHookTimeout(t *testing.T) {
+	t.Parallel()
+
+	t.Run("StartTimesOut", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.Append(Hook{
+			OnStart: func(context.Context) error {
+				<-release // ignores the context
+				return nil
+			},
+			OnStartName: "slowStart",
+			Timeout:     time.Millisecond,
+		})
+
+		err := l.Start(context.Background())
+		require.Error(t, err)
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStart", timeoutErr.Method)
+		assert.Equal(t, "slowStart", timeoutErr.FunctionName)
+		assert.NotEmpty(t, timeoutErr.Caller)
+		assert.Equal(t, time.Millisecond, timeoutErr.Timeout)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Contains(t, err.Error(), "OnStart hook slowStart appended by")
+		assert.Contains(t, err.Error(), "timed out after 1ms")
+
+		executed := spy.Events().SelectByTypeName("OnStartExecuted")
+		require.Len(t, executed, 1)
+		ev := executed[0].(*fxevent.OnStartExecuted)
+		assert.Equal(t, time.Millisecond, ev.Timeout)
+		assert.ErrorAs(t, ev.Err, &timeoutErr)
+	})
+
+	t.Run("StopTimesOutWithCtx", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{
+			OnStop: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Timeout: time.Millisecond,
+		})
+		l.Append(Hook{
+			OnStop:  func(context.Context) error { return nil },
+			Timeout: time.Hour,
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+		err := l.Stop(context.Background())
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStop", timeoutErr.Method)
+	})
+
+	t.Run("DumpsStack", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		spy := new(fxlog.Spy)
+		l := New(spy, fxclock.System)
+		l.DumpGoroutinesOnTimeout()
+		l.Append(Hook{
+			OnStart:     func(context.Context) error { return nil },
+			OnStartName: "fastStart",
+		})
+		l.Append(Hook{
+			OnStart: func(context.Context) error {
+				<-release // ignores the context
+				return nil
+			},
+			OnStartName: "slowStart",
+			Module:      "foo",
+			Timeout:     time.Millisecond,
+		})
+
+		err := l.Start(context.Background())
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "foo", timeoutErr.Module)
+		assert.Equal(t, []string{"fastStart"}, timeoutErr.Completed)
+		assert.Contains(t, timeoutErr.Stack, "TestLifecycleHookTimeout")
+		assert.Contains(t, err.Error(), `in module "foo" timed out after 1ms: context deadline exceeded`)
+
+		dumps := spy.Events().SelectByTypeName("GoroutineDump")
+		require.Len(t, dumps, 1)
+		dump := dumps[0].(*fxevent.GoroutineDump)
+		assert.Equal(t, "OnStart", dump.Method)
+		assert.Equal(t, "slowStart", dump.FunctionName)
+		assert.Equal(t, timeoutErr.Stack, dump.Stack)
+	})
+
+	t.Run("CallerDeadline", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{
+			OnStart: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			OnStartName: "slowStart",
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+
+		err := l.Start(ctx)
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "slowStart", timeoutErr.FunctionName)
+		assert.Empty(t, timeoutErr.Stack)
+	})
+
+	t.Run("CallerCtxCancelled", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		l := New(testLogger(t), fxclock.System)
+		l.Append(Hook{
+			OnStart: func(ctx context.Context) error {
+				cancel()
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			Timeout: time.Hour,
+		})
+
+		err := l.Start(ctx)
+		assert.ErrorIs(t, err, context.Canceled)
+		var timeoutErr *HookTimeoutError
+		assert.False(t, errors.As(err, &timeoutErr), "must not blame the hook")
+	})
+}
+
+func TestLifecycleContinueAfterDeadline(t *testing.T) {
+	t.Parallel()
+
+	expired := func(t *testing.T) context.Context {
+		ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+		t.Cleanup(cancel)
+		return ctx
+	}
+
+	t.Run("RunsRemainingHooks", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		var (
+			mu      sync.Mutex
+			stopped []string
+		)
+		stop := func(name string) {
+			mu.Lock()
+			defer mu.Unlock()
+			stopped = append(stopped, name)
+		}
+
+		l := New(testLogger(t), fxclock.System)
+		l.ContinueAfterDeadline(10 * time.Millisecond)
+		l.Append(Hook{
+			OnDrain:     func(context.Context) error { return nil },
+			OnDrainName: "drain",
+			OnStop: func(ctx context.Context) error {
+				assert.NoError(t, ctx.Err(), "hook must get a fresh context")
+				stop("first")
+				return nil
+			},
+		})
+		l.Append(Hook{
+			OnStop: func(context.Context) error {
+				stop("blocked")
+				<-release // ignores the context
+				return nil
+			},
+			OnStopName: "blockedStop",
+		})
+		l.Append(Hook{
+			OnStop: func(context.Context) error {
+				stop("failed")
+				return errors.New("great sadness")
+			},
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+		err := l.Stop(expired(t))
+		require.Error(t, err)
+		mu.Lock()
+		assert.Equal(t, []string{"failed", "blocked", "first"}, stopped)
+		mu.Unlock()
+
+		errs := multierr.Errors(err)
+		require.Len(t, errs, 3)
+		assert.ErrorIs(t, errs[0], context.DeadlineExceeded)
+		assert.Contains(t, errs[0].Error(), "OnDrain hook drain appended by")
+		assert.Contains(t, errs[0].Error(), "skipped")
+		assert.EqualError(t, errs[1], "great sadness")
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, errs[2], &timeoutErr)
+		assert.Equal(t, "blockedStop", timeoutErr.FunctionName)
+		assert.Equal(t, 10*time.Millisecond, timeoutErr.Timeout)
+	})
+
+	t.Run("GraceForRunningHook", func(t *testing.T) {
+		t.Parallel()
+
+		var stopped []string
+		l := New(testLogger(t), fxclock.System)
+		l.ContinueAfterDeadline(time.Minute)
+		l.Append(Hook{
+			OnStop: func(ctx context.Context) error {
+				assert.NoError(t, ctx.Err(), "hook must get a fresh context")
+				stopped = append(stopped, "first")
+				return nil
+			},
+		})
+		l.Append(Hook{
+			OnStop: func(ctx context.Context) error {
+				<-ctx.Done()
+				stopped = append(stopped, "second")
+				return nil // finishes cleaning up after the deadline
+			},
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		require.NoError(t, l.Stop(ctx))
+		assert.Equal(t, []string{"second", "first"}, stopped)
+	})
+
+	t.Run("AbandonsRunningHook", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		var firstStopped bool
+		l := New(testLogger(t), fxclock.System)
+		l.ContinueAfterDeadline(time.Millisecond)
+		l.Append(Hook{
+			OnStop: func(context.Context) error {
+				firstStopped = true
+				return nil
+			},
+		})
+		l.Append(Hook{
+			OnStop: func(context.Context) error {
+				<-release // ignores the context
+				return nil
+			},
+			OnStopName: "blockedStop",
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond)
+		defer cancel()
+		err := l.Stop(ctx)
+
+		var timeoutErr *HookTimeoutError
+		require.ErrorAs(t, err, &timeoutErr)
+		assert.Equal(t, "OnStop", timeoutErr.Method)
+		assert.Equal(t, "blockedStop", timeoutErr.FunctionName)
+		assert.True(t, firstStopped, "remaining hooks must run")
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		t.Parallel()
+
+		var numStopped atomic.Int32
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+		l.ContinueAfterDeadline(time.Minute)
+		for i := 0; i < 3; i++ {
+			l.Append(Hook{
+				Owner: &testOwner{},
+				OnStop: func(ctx context.Context) error {
+					assert.NoError(t, ctx.Err(), "hook must get a fresh context")
+					numStopped.Inc()
+					return nil
+				},
+			})
+		}
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(expired(t)))
+		assert.Equal(t, int32(3), numStopped.Load())
+	})
+}
+
+// fakeClock is an fxclock.Clock whose timeouts expire immediately. It
+// records the durations it was asked to wait for.
+type fakeClock struct {
+	mu    sync.Mutex
+	now   time.Time
+	waits []time.Duration
+}
+
+var _ fxclock.Clock = (*fakeClock)(nil)
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Since(t time.Time) time.Duration { return c.Now().Sub(t) }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func (c *fakeClock) WithTimeout(ctx context.Context, d time.Duration) (context.Context, context.CancelFunc) {
+	c.mu.Lock()
+	c.waits = append(c.waits, d)
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+	return context.WithDeadline(ctx, time.Time{})
+}
+
+func TestLifecycleStartRetry(t *testing.T) {
+	t.Parallel()
+
+	backoff := func(attempt int) (time.Duration, bool) {
+		return time.Duration(attempt) * time.Second, attempt < 3
+	}
+
+	t.Run("SucceedsAfterRetries", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		l := New(spy, clock)
+
+		errTransient := errors.New("transient")
+		calls := 0
+		l.Append(Hook{
+			OnStart: func(context.Context) error {
+				calls++
+				if calls < 3 {
+					return errTransient
+				}
+				return nil
+			},
+			StartRetry: backoff,
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, clock.waits)
+
+		retrying := spy.Events().SelectByTypeName("OnStartRetrying")
+		require.Len(t, retrying, 2)
+		for i, ev := range retrying {
+			ev := ev.(*fxevent.OnStartRetrying)
+			assert.Equal(t, i+1, ev.Attempt)
+			assert.Equal(t, time.Duration(i+1)*time.Second, ev.Backoff)
+			assert.Equal(t, errTransient, ev.Err)
+		}
+
+		executed := spy.Events().SelectByTypeName("OnStartExecuted")
+		require.Len(t, executed, 1)
+		assert.NoError(t, executed[0].(*fxevent.OnStartExecuted).Err)
+		assert.Equal(t, 3*time.Second, executed[0].(*fxevent.OnStartExecuted).Runtime)
+	})
+
+	t.Run("GivesUp", func(t *testing.T) {
+		t.Parallel()
+
+		clock := &fakeClock{now: time.Unix(0, 0)}
+		l := New(testLogger(t), clock)
+
+		err := errors.New("permanent")
+		calls := 0
+		l.Append(Hook{
+			OnStart: func(context.Context) error {
+				calls++
+				return err
+			},
+			StartRetry: backoff,
+		})
+
+		assert.Equal(t, err, l.Start(context.Background()))
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("StopsBeforeDeadline", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		clock := &fakeClock{now: now}
+		l := New(testLogger(t), clock)
+
+		err := errors.New("permanent")
+		calls := 0
+		l.Append(Hook{
+			OnStart: func(context.Context) error {
+				calls++
+				return err
+			},
+			StartRetry: func(int) (time.Duration, bool) {
+				return time.Second, true
+			},
+		})
+
+		// Leaves room for exactly two retries.
+		ctx, cancel := context.WithDeadline(context.Background(), now.Add(2500*time.Millisecond))
+		defer cancel()
+		assert.Equal(t, err, l.Start(ctx))
+		assert.Equal(t, 3, calls)
+	})
+}
+
+// testOwner is a HookOwner that depends on the owners listed in deps.
+type testOwner struct {
+	deps []*testOwner
+}
+
+func (o *testOwner) DependsOn(other HookOwner) bool {
+	for _, d := range o.deps {
+		if d == other || d.DependsOn(other) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLifecycleConcurrent(t *testing.T) {
+	t.Parallel()
+
+	t.Run("IndependentHooksOverlap", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+
+		aStarted := make(chan struct{})
+		bStarted := make(chan struct{})
+		l.Append(Hook{
+			Owner: &testOwner{},
+			OnStart: func(context.Context) error {
+				close(aStarted)
+				<-bStarted
+				return nil
+			},
+		})
+		l.Append(Hook{
+			Owner: &testOwner{},
+			OnStart: func(context.Context) error {
+				close(bStarted)
+				<-aStarted
+				return nil
+			},
+		})
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+	})
+
+	t.Run("DependencyOrder", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+
+		var (
+			mu     sync.Mutex
+			events []string
+		)
+		record := func(ev string) func(context.Context) error {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, ev)
+				return nil
+			}
+		}
+
+		a := &testOwner{}
+		b := &testOwner{deps: []*testOwner{a}}
+		c := &testOwner{deps: []*testOwner{b}}
+		l.Append(Hook{Owner: a, OnStart: record("start a"), OnStop: record("stop a")})
+		l.Append(Hook{Owner: b, OnStart: record("start b"), OnStop: record("stop b")})
+		l.Append(Hook{Owner: c, OnStart: record("start c"), OnStop: record("stop c")})
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{
+			"start a", "start b", "start c",
+			"stop c", "stop b", "stop a",
+		}, events)
+	})
+
+	t.Run("NilOwnerIsBarrier", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+
+		var (
+			mu     sync.Mutex
+			events []string
+		)
+		record := func(ev string) func(context.Context) error {
+			return func(context.Context) error {
+				mu.Lock()
+				defer mu.Unlock()
+				events = append(events, ev)
+				return nil
+			}
+		}
+
+		l.Append(Hook{Owner: &testOwner{}, OnStart: record("first")})
+		l.Append(Hook{OnStart: record("barrier")})
+		l.Append(Hook{Owner: &testOwner{}, OnStart: record("last")})
+
+		require.NoError(t, l.Start(context.Background()))
+		require.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{"first", "barrier", "last"}, events)
+	})
+
+	t.Run("ErrStopsStartedHooksOnly", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+
+		err := errors.New("a starter error")
+		a := &testOwner{}
+		b := &testOwner{deps: []*testOwner{a}}
+		c := &testOwner{deps: []*testOwner{b}}
+
+		var stopped []string
+		l.Append(Hook{
+			Owner:   a,
+			OnStart: func(context.Context) error { return nil },
+			OnStop: func(context.Context) error {
+				stopped = append(stopped, "a")
+				return nil
+			},
+		})
+		l.Append(Hook{
+			Owner:   b,
+			OnStart: func(context.Context) error { return err },
+			OnStop: func(context.Context) error {
+				assert.Fail(t, "this stopper shouldn't run, since the starter failed")
+				return nil
+			},
+		})
+		l.Append(Hook{
+			Owner: c,
+			OnStart: func(context.Context) error {
+				assert.Fail(t, "this starter should never run, since a dependency failed")
+				return nil
+			},
+		})
+
+		assert.Equal(t, err, l.Start(context.Background()))
+		assert.NoError(t, l.Stop(context.Background()))
+		assert.Equal(t, []string{"a"}, stopped)
+	})
+
+	t.Run("DoNotRunStopHooksWithExpiredCtx", func(t *testing.T) {
+		t.Parallel()
+
+		l := New(testLogger(t), fxclock.System)
+		l.RunConcurrently()
+		l.Append(Hook{
+			Owner: &testOwner{},
+			OnStop: func(context.Context) error {
+				assert.Fail(t, "this hook should not run")
+				return nil
+			},
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		require.NoError(t, l.Start(ctx))
+		cancel()
+		require.ErrorIs(t, l.Stop(ctx), context.Canceled)
+	})
+}
+
+func TestLifecycleRecords(t *testing.T) {
+	t.Parallel()
+
+	l := New(testLogger(t), fxclock.System)
+	err := errors.New("great sadness")
+	l.Append(Hook{
+		OnStart:     func(context.Context) error { return nil },
+		OnStop:      func(context.Context) error { return err },
+		OnStartName: "start",
+		OnStopName:  "stop",
+		Module:      "foo",
+	})
+	l.Append(Hook{
+		OnStart:     func(context.Context) error { return err },
+		OnStartName: "fail",
+	})
+
+	records, _ := l.StartRecords()
+	assert.Empty(t, records, "no records before start")
+
+	require.Equal(t, err, l.Start(context.Background()))
+	records, total := l.StartRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, "OnStart", records[0].Method)
+	assert.Equal(t, "start", records[0].FuncName)
+	assert.Equal(t, "foo", records[0].Module)
+	assert.NoError(t, records[0].Err)
+	assert.Equal(t, "fail", records[1].FuncName)
+	assert.Equal(t, err, records[1].Err, "failed hooks must be recorded")
+	assert.Positive(t, total)
+
+	require.Equal(t, err, l.Stop(context.Background()))
+	records, _ = l.StopRecords()
+	require.Len(t, records, 1)
+	assert.Equal(t, "OnStop", records[0].Method)
+	assert.Equal(t, "stop", records[0].FuncName)
+	assert.Equal(t, err, records[0].Err)
+}
+
 func TestHookRecordsFormat(t *testing.T) {
 	t.Parallel()
 
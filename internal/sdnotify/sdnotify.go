@@ -0,0 +1,162 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Package sdnotify implements the client side of the systemd service
+// notification protocol described in sd_notify(3).
+package sdnotify
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// States understood by the service manager.
+const (
+	Ready     = "READY=1"
+	Reloading = "RELOADING=1"
+	Stopping  = "STOPPING=1"
+	Watchdog  = "WATCHDOG=1"
+)
+
+// Notifier sends state notifications to the service manager.
+//
+// A nil Notifier discards all notifications, so callers don't have to check
+// whether the process runs under systemd.
+type Notifier struct {
+	addr string
+
+	// How often the watchdog expects to hear from us, or zero if the
+	// watchdog is disabled for this process.
+	watchdog time.Duration
+
+	mu   sync.Mutex
+	stop chan struct{} // closed to stop the watchdog
+	done chan struct{} // closed when the watchdog has stopped
+}
+
+// FromEnv builds a Notifier from the NOTIFY_SOCKET, WATCHDOG_USEC, and
+// WATCHDOG_PID environment variables, looked up with getenv. It returns nil
+// if NOTIFY_SOCKET is not set.
+func FromEnv(getenv func(string) string) (*Notifier, error) {
+	addr := getenv("NOTIFY_SOCKET")
+	if addr == "" {
+		return nil, nil
+	}
+
+	n := &Notifier{addr: addr}
+	if usec := getenv("WATCHDOG_USEC"); usec != "" {
+		v, err := strconv.ParseUint(usec, 10, 63)
+		if err != nil || v == 0 {
+			return nil, fmt.Errorf("invalid WATCHDOG_USEC %q", usec)
+		}
+
+		// The watchdog may be meant for another process, for
+		// example our parent.
+		if pid := getenv("WATCHDOG_PID"); pid == "" || pid == strconv.Itoa(os.Getpid()) {
+			n.watchdog = time.Duration(v) * time.Microsecond
+		}
+	}
+	return n, nil
+}
+
+// WatchdogInterval returns how often the service manager expects watchdog
+// notifications, or zero if the watchdog is disabled.
+func (n *Notifier) WatchdogInterval() time.Duration {
+	if n == nil {
+		return 0
+	}
+	return n.watchdog
+}
+
+// Notify sends the given states to the service manager in a single
+// datagram.
+func (n *Notifier) Notify(states ...string) error {
+	if n == nil {
+		return nil
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: n.addr, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(strings.Join(states, "\n")))
+	return err
+}
+
+// StartWatchdog starts sending Watchdog notifications at half the watchdog
+// interval, as recommended by sd_watchdog_enabled(3). It does nothing if the
+// watchdog is disabled or already running.
+func (n *Notifier) StartWatchdog() {
+	if n.WatchdogInterval() == 0 {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.stop != nil {
+		return
+	}
+	n.stop = make(chan struct{})
+	n.done = make(chan struct{})
+	go n.pingWatchdog(n.stop, n.done)
+}
+
+// StopWatchdog stops sending Watchdog notifications and waits for the
+// watchdog to stop. It does nothing if the watchdog is not running.
+func (n *Notifier) StopWatchdog() {
+	if n == nil {
+		return
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.stop == nil {
+		return
+	}
+	close(n.stop)
+	<-n.done
+	n.stop, n.done = nil, nil
+}
+
+func (n *Notifier) pingWatchdog(stop <-chan struct{}, done chan<- struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(n.watchdog / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			// A missed ping is recovered from by the next one.
+			_ = n.Notify(Watchdog)
+		}
+	}
+}
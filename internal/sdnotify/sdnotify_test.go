@@ -0,0 +1,203 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sdnotify
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// listen opens a socket standing in for the service manager and returns
+// the environment that points a Notifier at it.
+func listen(t *testing.T) (*net.UnixConn, map[string]string) {
+	addr := filepath.Join(t.TempDir(), "notify.sock")
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"})
+	if err != nil {
+		t.Skipf("unixgram sockets are not supported: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return conn, map[string]string{"NOTIFY_SOCKET": addr}
+}
+
+// read returns the next datagram received on conn.
+func read(t *testing.T, conn *net.UnixConn) string {
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 1024)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	return string(buf[:n])
+}
+
+func getenv(env map[string]string) func(string) string {
+	return func(key string) string { return env[key] }
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Parallel()
+
+	pid := strconv.Itoa(os.Getpid())
+	tests := []struct {
+		desc string
+		env  map[string]string
+
+		wantNil      bool
+		wantWatchdog time.Duration
+		wantErr      string
+	}{
+		{
+			desc:    "not under systemd",
+			env:     map[string]string{"WATCHDOG_USEC": "1000"},
+			wantNil: true,
+		},
+		{
+			desc: "no watchdog",
+			env:  map[string]string{"NOTIFY_SOCKET": "/run/notify"},
+		},
+		{
+			desc: "watchdog",
+			env: map[string]string{
+				"NOTIFY_SOCKET": "/run/notify",
+				"WATCHDOG_USEC": "30000000",
+			},
+			wantWatchdog: 30 * time.Second,
+		},
+		{
+			desc: "watchdog for this process",
+			env: map[string]string{
+				"NOTIFY_SOCKET": "/run/notify",
+				"WATCHDOG_USEC": "1000",
+				"WATCHDOG_PID":  pid,
+			},
+			wantWatchdog: time.Millisecond,
+		},
+		{
+			desc: "watchdog for another process",
+			env: map[string]string{
+				"NOTIFY_SOCKET": "/run/notify",
+				"WATCHDOG_USEC": "1000",
+				"WATCHDOG_PID":  pid + "0",
+			},
+		},
+		{
+			desc: "invalid watchdog",
+			env: map[string]string{
+				"NOTIFY_SOCKET": "/run/notify",
+				"WATCHDOG_USEC": "soon",
+			},
+			wantErr: `invalid WATCHDOG_USEC "soon"`,
+		},
+		{
+			desc: "zero watchdog",
+			env: map[string]string{
+				"NOTIFY_SOCKET": "/run/notify",
+				"WATCHDOG_USEC": "0",
+			},
+			wantErr: `invalid WATCHDOG_USEC "0"`,
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.desc, func(t *testing.T) {
+			t.Parallel()
+
+			n, err := FromEnv(getenv(tt.env))
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, n)
+				return
+			}
+			require.NotNil(t, n)
+			assert.Equal(t, tt.wantWatchdog, n.WatchdogInterval())
+		})
+	}
+}
+
+func TestNotify(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sends states", func(t *testing.T) {
+		t.Parallel()
+
+		conn, env := listen(t)
+		n, err := FromEnv(getenv(env))
+		require.NoError(t, err)
+
+		require.NoError(t, n.Notify(Ready))
+		assert.Equal(t, "READY=1", read(t, conn))
+
+		require.NoError(t, n.Notify(Stopping, "STATUS=bye"))
+		assert.Equal(t, "STOPPING=1\nSTATUS=bye", read(t, conn))
+	})
+
+	t.Run("nil", func(t *testing.T) {
+		t.Parallel()
+
+		var n *Notifier
+		assert.NoError(t, n.Notify(Ready))
+		assert.Zero(t, n.WatchdogInterval())
+		n.StartWatchdog()
+		n.StopWatchdog()
+	})
+
+	t.Run("no listener", func(t *testing.T) {
+		t.Parallel()
+
+		n, err := FromEnv(getenv(map[string]string{
+			"NOTIFY_SOCKET": filepath.Join(t.TempDir(), "missing.sock"),
+		}))
+		require.NoError(t, err)
+		assert.Error(t, n.Notify(Ready))
+	})
+}
+
+func TestWatchdog(t *testing.T) {
+	t.Parallel()
+
+	conn, env := listen(t)
+	env["WATCHDOG_USEC"] = "10000"
+	n, err := FromEnv(getenv(env))
+	require.NoError(t, err)
+
+	n.StartWatchdog()
+	n.StartWatchdog() // no-op while running
+	assert.Equal(t, "WATCHDOG=1", read(t, conn))
+	assert.Equal(t, "WATCHDOG=1", read(t, conn))
+	n.StopWatchdog()
+	n.StopWatchdog() // no-op once stopped
+
+	// Restarting the watchdog resumes the pings.
+	n.StartWatchdog()
+	defer n.StopWatchdog()
+	assert.Equal(t, "WATCHDOG=1", read(t, conn))
+}
@@ -22,6 +22,8 @@ package fx
 
 import (
 	"context"
+	"math/rand"
+	"time"
 
 	"go.uber.org/fx/internal/lifecycle"
 )
@@ -42,12 +44,123 @@ type Lifecycle interface {
 // If a Hook's OnStart callback isn't executed (because a previous OnStart
 // failure short-circuited application startup), its OnStop callback won't be
 // executed.
+//
+// A Hook may additionally specify OnReady and OnDrain callbacks. OnReady
+// callbacks run, in the order hooks were appended, only after the OnStart
+// callbacks of all hooks have succeeded; use them to begin accepting
+// traffic once every component is up. OnDrain callbacks run, in reverse
+// order, before any OnStop callback; use them to stop accepting new work
+// before anything is torn down. If an OnReady callback fails, startup
+// fails, and the OnDrain callbacks of hooks that became ready run before
+// the usual OnStop rollback.
+//
+// A Hook may also specify an OnReload callback, which runs only when the
+// started application is asked to reload, either with App.Reload or by
+// receiving SIGHUP. OnReload callbacks run in the order hooks were appended;
+// use them to re-read configuration or reopen log files. A failed OnReload
+// callback doesn't stop the application.
+//
+// If Timeout is positive, each of the hook's callbacks must return within
+// that duration. The context passed to them is cancelled when the timeout
+// expires, and the hook fails with a [HookTimeoutError]. Hook timeouts
+// apply in addition to the application's StartTimeout and StopTimeout.
+//
+// If StartRetry is set, a failed OnStart is retried as specified by the
+// [RetryPolicy]. Timeout applies to each attempt individually.
 type Hook struct {
-	OnStart func(context.Context) error
-	OnStop  func(context.Context) error
+	OnStart    func(context.Context) error
+	OnStop     func(context.Context) error
+	OnReady    func(context.Context) error
+	OnDrain    func(context.Context) error
+	OnReload   func(context.Context) error
+	Timeout    time.Duration
+	StartRetry *RetryPolicy
+
+	onStartName  string
+	onStopName   string
+	onReadyName  string
+	onDrainName  string
+	onReloadName string
+}
+
+// HookTimeoutError is the error returned by a hook that did not finish
+// within its [Hook.Timeout], and by App.Start and App.Stop when a hook is
+// still running at the deadline of the context passed to them. It names the
+// hook function, the function and module that appended it to the
+// Lifecycle, and the hooks that had already finished. With
+// [DumpGoroutinesOnTimeout], it also holds the stack trace of the hook.
+//
+// errors.Is reports true when a HookTimeoutError is compared against
+// context.DeadlineExceeded.
+type HookTimeoutError = lifecycle.HookTimeoutError
+
+// RetryPolicy specifies how a failed OnStart hook is retried. Attempts are
+// spaced out with exponential backoff and jitter, and Fx stops retrying once
+// the next attempt would begin after the start deadline.
+//
+//	lc.Append(fx.Hook{
+//		OnStart: conn.Dial,
+//		StartRetry: &fx.RetryPolicy{
+//			MaxAttempts:    5,
+//			InitialBackoff: 100 * time.Millisecond,
+//			Jitter:         0.2,
+//		},
+//	})
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of times OnStart is called,
+	// including the first attempt. If zero, OnStart is retried until it
+	// succeeds or the start deadline is reached.
+	MaxAttempts int
+
+	// InitialBackoff is how long to wait before the first retry.
+	// Defaults to 100 milliseconds.
+	InitialBackoff time.Duration
+
+	// MaxBackoff, if positive, caps the wait between attempts.
+	MaxBackoff time.Duration
 
-	onStartName string
-	onStopName  string
+	// Multiplier is the factor by which the backoff grows after each
+	// attempt. Defaults to 2.
+	Multiplier float64
+
+	// Jitter randomizes each backoff by up to this fraction of its value
+	// in either direction. It must be between 0 and 1.
+	Jitter float64
+}
+
+const _defaultInitialBackoff = 100 * time.Millisecond
+
+// Backoff reports how long to wait after the given number of failed
+// attempts, or false if no more attempts should be made.
+func (p *RetryPolicy) Backoff(attempt int) (time.Duration, bool) {
+	if p.MaxAttempts > 0 && attempt >= p.MaxAttempts {
+		return 0, false
+	}
+
+	d := float64(p.InitialBackoff)
+	if d <= 0 {
+		d = float64(_defaultInitialBackoff)
+	}
+	mult := p.Multiplier
+	if mult < 1 {
+		mult = 2
+	}
+	for i := 1; i < attempt; i++ {
+		d *= mult
+		if p.MaxBackoff > 0 && d >= float64(p.MaxBackoff) {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && d > float64(p.MaxBackoff) {
+		d = float64(p.MaxBackoff)
+	}
+	if j := p.Jitter; j > 0 {
+		if j > 1 {
+			j = 1
+		}
+		d += d * j * (2*rand.Float64() - 1)
+	}
+	return time.Duration(d), true
 }
 
 // StartHook returns a new Hook with start as its [Hook.OnStart] function,
@@ -133,15 +246,93 @@ func StartStopHook[T1 HookFunc, T2 HookFunc](start T1, stop T2) Hook {
 	}
 }
 
+// ReadyHook returns a new Hook with ready as its [Hook.OnReady] function,
+// wrapping its signature as needed in the same way as [StartHook].
+func ReadyHook[T HookFunc](ready T) Hook {
+	onready, readyname := lifecycle.Wrap(ready)
+
+	return Hook{
+		OnReady:     onready,
+		onReadyName: readyname,
+	}
+}
+
+// DrainHook returns a new Hook with drain as its [Hook.OnDrain] function,
+// wrapping its signature as needed in the same way as [StopHook].
+func DrainHook[T HookFunc](drain T) Hook {
+	ondrain, drainname := lifecycle.Wrap(drain)
+
+	return Hook{
+		OnDrain:     ondrain,
+		onDrainName: drainname,
+	}
+}
+
+// ReadyDrainHook returns a new Hook with ready as its [Hook.OnReady]
+// function and drain as its [Hook.OnDrain] function, independently wrapping
+// the signature of each as needed.
+func ReadyDrainHook[T1 HookFunc, T2 HookFunc](ready T1, drain T2) Hook {
+	var (
+		onready, readyname = lifecycle.Wrap(ready)
+		ondrain, drainname = lifecycle.Wrap(drain)
+	)
+
+	return Hook{
+		OnReady:     onready,
+		OnDrain:     ondrain,
+		onReadyName: readyname,
+		onDrainName: drainname,
+	}
+}
+
+// ReloadHook returns a new Hook with reload as its [Hook.OnReload] function,
+// wrapping its signature as needed in the same way as [StartHook].
+func ReloadHook[T HookFunc](reload T) Hook {
+	onreload, reloadname := lifecycle.Wrap(reload)
+
+	return Hook{
+		OnReload:     onreload,
+		onReloadName: reloadname,
+	}
+}
+
 type lifecycleWrapper struct {
 	*lifecycle.Lifecycle
+
+	app *App
 }
 
 func (l *lifecycleWrapper) Append(h Hook) {
+	// runningCtor and invokingModule only change while New runs
+	// constructors and invokes, which happens before any hook runs. Hooks
+	// appended by running hooks, possibly from other goroutines, read them
+	// after New has returned and get neither an owner nor a module.
+	var owner lifecycle.HookOwner
+	moduleName := l.app.invokingModule
+	if ctor := l.app.runningCtor; ctor != nil {
+		owner = ctor
+		moduleName = ctor.module.name
+	}
+
+	var startRetry func(int) (time.Duration, bool)
+	if h.StartRetry != nil {
+		startRetry = h.StartRetry.Backoff
+	}
+
 	l.Lifecycle.Append(lifecycle.Hook{
-		OnStart:     h.OnStart,
-		OnStop:      h.OnStop,
-		OnStartName: h.onStartName,
-		OnStopName:  h.onStopName,
+		OnStart:      h.OnStart,
+		OnStop:       h.OnStop,
+		OnReady:      h.OnReady,
+		OnDrain:      h.OnDrain,
+		OnReload:     h.OnReload,
+		OnStartName:  h.onStartName,
+		OnStopName:   h.onStopName,
+		OnReadyName:  h.onReadyName,
+		OnDrainName:  h.onDrainName,
+		OnReloadName: h.onReloadName,
+		Timeout:      h.Timeout,
+		StartRetry:   startRetry,
+		Owner:        owner,
+		Module:       moduleName,
 	})
 }
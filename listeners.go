@@ -0,0 +1,401 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Environment variables used to pass listeners to a new process. The
+// LISTEN_* variables follow the systemd socket activation protocol described
+// in sd_listen_fds(3), so applications can also inherit sockets from
+// systemd.
+const (
+	_listenPIDEnv     = "LISTEN_PID"
+	_listenFDsEnv     = "LISTEN_FDS"
+	_listenFDNamesEnv = "LISTEN_FDNAMES"
+	_upgradeReadyEnv  = "FX_UPGRADE_READY_FD"
+
+	// First file descriptor passed by the parent process.
+	_listenFDsStart = 3
+)
+
+// Listeners creates the network listeners of an application. If the
+// application was started by App.Upgrade of another Fx application, or by
+// systemd with socket activation, Listeners hands out the listeners passed
+// down by the parent process instead of creating new ones, so that no
+// connection is refused while one process replaces another.
+//
+// Listeners is provided to all Fx applications.
+//
+//	fx.Invoke(func(lc fx.Lifecycle, ls fx.Listeners, srv *http.Server) error {
+//		ln, err := ls.Listen("tcp", ":8080")
+//		if err != nil {
+//			return err
+//		}
+//		lc.Append(fx.StartStopHook(
+//			func() { go srv.Serve(ln) },
+//			srv.Shutdown,
+//		))
+//		return nil
+//	})
+type Listeners interface {
+	// Listen announces on the local network address like net.Listen. If
+	// the parent process passed down a listener for the same network and
+	// address, Listen returns it instead of creating a new one.
+	//
+	// Listeners returned by Listen are passed on to the new process by
+	// App.Upgrade. Closing them remains the caller's responsibility.
+	Listen(network, address string) (net.Listener, error)
+}
+
+type namedListener struct {
+	name string
+	ln   net.Listener
+}
+
+// listenerRegistry implements Listeners.
+type listenerRegistry struct {
+	mu sync.Mutex
+
+	inheritOnce sync.Once
+	inheritErr  error
+
+	// Listeners passed down by the parent process that haven't been
+	// claimed by Listen yet.
+	inherited []namedListener
+
+	// Listeners handed out by Listen.
+	active []namedListener
+
+	// Written to and closed once the application has started, if the
+	// application was started by App.Upgrade.
+	ready *os.File
+}
+
+var _ Listeners = (*listenerRegistry)(nil)
+
+// listenerName names a listener for LISTEN_FDNAMES. Names may not contain
+// colons, which separate them.
+func listenerName(network, address string) string {
+	return url.QueryEscape(network + ":" + address)
+}
+
+func (r *listenerRegistry) Listen(network, address string) (net.Listener, error) {
+	if err := r.inherit(); err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := listenerName(network, address)
+	ln, err := r.claim(name, network, address)
+	if ln == nil && err == nil {
+		ln, err = net.Listen(network, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	r.active = append(r.active, namedListener{name: name, ln: ln})
+	return ln, nil
+}
+
+// claim removes and returns the inherited listener with the given name,
+// or, failing that, the first one listening on the given address. This lets
+// us match sockets passed by systemd, which we didn't name. It returns nil if
+// there's no such listener.
+//
+// This method must be invoked under locked mutex.
+func (r *listenerRegistry) claim(name, network, address string) (net.Listener, error) {
+	idx := -1
+	for i, nl := range r.inherited {
+		if nl.name == name {
+			idx = i
+			break
+		}
+	}
+	if idx < 0 {
+		for i, nl := range r.inherited {
+			addr := nl.ln.Addr()
+			if addr.Network() == network && addr.String() == address {
+				idx = i
+				break
+			}
+		}
+	}
+	if idx < 0 {
+		return nil, nil
+	}
+
+	ln := r.inherited[idx].ln
+	r.inherited = append(r.inherited[:idx], r.inherited[idx+1:]...)
+	return ln, nil
+}
+
+// inherit takes over the listeners passed down by the parent process, if
+// any. It only does so once, and clears the environment variables that
+// describe them so that our own children don't inherit them by accident.
+func (r *listenerRegistry) inherit() error {
+	r.inheritOnce.Do(func() {
+		r.inheritErr = r.inheritListeners()
+	})
+	return r.inheritErr
+}
+
+func (r *listenerRegistry) inheritListeners() error {
+	defer func() {
+		for _, key := range []string{_listenPIDEnv, _listenFDsEnv, _listenFDNamesEnv} {
+			os.Unsetenv(key)
+		}
+	}()
+
+	// The listeners may be meant for another process. App.Upgrade
+	// doesn't set LISTEN_PID since it can't know the new process's PID
+	// in advance.
+	if pid := os.Getenv(_listenPIDEnv); pid != "" && pid != strconv.Itoa(os.Getpid()) {
+		return nil
+	}
+
+	fds := os.Getenv(_listenFDsEnv)
+	if fds == "" {
+		return nil
+	}
+	n, err := strconv.Atoi(fds)
+	if err != nil || n < 0 {
+		return fmt.Errorf("invalid %v %q", _listenFDsEnv, fds)
+	}
+
+	var names []string
+	if s := os.Getenv(_listenFDNamesEnv); s != "" {
+		names = strings.Split(s, ":")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := 0; i < n; i++ {
+		var name string
+		if i < len(names) {
+			name = names[i]
+		}
+
+		f := os.NewFile(uintptr(_listenFDsStart+i), name)
+		ln, err := net.FileListener(f)
+		f.Close() // FileListener uses a copy of the descriptor
+		if err != nil {
+			return fmt.Errorf("inherit listener %d (%q): %w", i, name, err)
+		}
+		r.inherited = append(r.inherited, namedListener{name: name, ln: ln})
+	}
+	return nil
+}
+
+// inheritUpgrade prepares to report readiness to the parent process if the
+// application was started by App.Upgrade. Such applications take over their
+// listeners right away: they're meant for us and would leak otherwise.
+func (r *listenerRegistry) inheritUpgrade() error {
+	s := os.Getenv(_upgradeReadyEnv)
+	if s == "" {
+		return nil
+	}
+	os.Unsetenv(_upgradeReadyEnv)
+
+	fd, err := strconv.Atoi(s)
+	if err != nil || fd < _listenFDsStart {
+		return fmt.Errorf("invalid %v %q", _upgradeReadyEnv, s)
+	}
+	r.ready = os.NewFile(uintptr(fd), "fx-upgrade-ready")
+	return r.inherit()
+}
+
+// started closes inherited listeners that nobody claimed, and tells the
+// parent process that we're ready if it's waiting on us.
+func (r *listenerRegistry) started() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, nl := range r.inherited {
+		nl.ln.Close()
+	}
+	r.inherited = nil
+
+	if r.ready != nil {
+		// If the write fails, the parent will notice us closing the
+		// pipe and give up on us.
+		r.ready.Write([]byte{1})
+		r.ready.Close()
+		r.ready = nil
+	}
+}
+
+// files returns copies of the file descriptors of all listeners handed out
+// by Listen, along with their names.
+func (r *listenerRegistry) files() (files []*os.File, names []string, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, nl := range r.active {
+		fl, ok := nl.ln.(interface{ File() (*os.File, error) })
+		if !ok {
+			err = fmt.Errorf("listener %v of type %T cannot be passed on", nl.ln.Addr(), nl.ln)
+			break
+		}
+		f, ferr := fl.File()
+		if ferr != nil {
+			err = fmt.Errorf("listener %v cannot be passed on: %w", nl.ln.Addr(), ferr)
+			break
+		}
+		files = append(files, f)
+		names = append(names, nl.name)
+	}
+
+	if err != nil {
+		closeFiles(files)
+		return nil, nil, err
+	}
+	return files, names, nil
+}
+
+func closeFiles(files []*os.File) {
+	for _, f := range files {
+		f.Close()
+	}
+}
+
+// Upgrade replaces the running application with a new process running the
+// same executable with the same arguments, without refusing any connection.
+// This allows upgrading the binary of an application in place.
+//
+// The new process inherits all listeners created with Listeners, and receives
+// them from its own Listeners when it asks for the same network and address.
+// Upgrade waits for the new process to start successfully, then stops this
+// application as Stop does and broadcasts a shutdown signal to the channels
+// returned by Done and Wait, so that Run returns. If the new process exits or
+// ctx ends before it's ready, Upgrade kills it and returns an error, and this
+// application keeps running.
+//
+// The provided context bounds the entire upgrade. Upgrade is not supported on
+// Windows.
+func (app *App) Upgrade(ctx context.Context) error {
+	if app.err != nil {
+		return app.err
+	}
+	if state := app.State(); state != StateStarted {
+		return fmt.Errorf("attempted to upgrade application when in state: %v", state)
+	}
+
+	if err := app.startUpgrade(ctx); err != nil {
+		return fmt.Errorf("upgrade failed: %w", err)
+	}
+
+	if err := app.Stop(ctx); err != nil {
+		return err
+	}
+
+	// Nobody may be listening on Done or Wait.
+	_ = app.receivers.Broadcast(ShutdownSignal{Signal: _sigTERM})
+	return nil
+}
+
+// startUpgrade starts the new process and waits until it's ready.
+func (app *App) startUpgrade(ctx context.Context) error {
+	files, names, err := app.listeners.files()
+	if err != nil {
+		return err
+	}
+	defer closeFiles(files)
+
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+
+	readyR, readyW, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	defer readyR.Close()
+
+	cmd := exec.Command(exe, os.Args[1:]...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.ExtraFiles = append(files, readyW)
+	cmd.Env = append(upgradeEnviron(),
+		fmt.Sprintf("%v=%d", _listenFDsEnv, len(files)),
+		fmt.Sprintf("%v=%v", _listenFDNamesEnv, strings.Join(names, ":")),
+		fmt.Sprintf("%v=%d", _upgradeReadyEnv, _listenFDsStart+len(files)),
+	)
+	err = cmd.Start()
+	readyW.Close() // so that we see EOF if the new process exits
+	if err != nil {
+		return err
+	}
+
+	ready := make(chan error, 1)
+	go func() {
+		_, err := readyR.Read(make([]byte, 1))
+		if errors.Is(err, io.EOF) {
+			err = errors.New("new process exited before it was ready")
+		}
+		ready <- err
+	}()
+
+	select {
+	case err = <-ready:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil {
+		cmd.Process.Kill()
+		cmd.Wait()
+		return err
+	}
+
+	// The new process outlives us.
+	return cmd.Process.Release()
+}
+
+// upgradeEnviron returns our environment, minus the variables that describe
+// the listeners we pass on.
+func upgradeEnviron() []string {
+	var env []string
+	for _, kv := range os.Environ() {
+		switch strings.SplitN(kv, "=", 2)[0] {
+		case _listenPIDEnv, _listenFDsEnv, _listenFDNamesEnv, _upgradeReadyEnv:
+			continue
+		}
+		env = append(env, kv)
+	}
+	return env
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+)
+
+// _upgradeChildEnv makes the test binary act as the new process started by
+// App.Upgrade instead of running tests. Its value selects the behavior.
+const _upgradeChildEnv = "FX_TEST_UPGRADE_CHILD"
+
+// runUpgradeChild starts an application that serves a single connection on
+// the listener it inherits, and returns the exit code of the process.
+func runUpgradeChild(mode string) int {
+	if mode == "fail" {
+		return 1
+	}
+
+	served := make(chan struct{})
+	app := New(
+		NopLogger,
+		Invoke(func(lc Lifecycle, ls Listeners) error {
+			ln, err := ls.Listen("tcp", "127.0.0.1:0")
+			if err != nil {
+				return err
+			}
+			lc.Append(StartStopHook(
+				func() {
+					go func() {
+						defer close(served)
+						conn, err := ln.Accept()
+						if err != nil {
+							return
+						}
+						defer conn.Close()
+						conn.Write([]byte("child\n"))
+					}()
+				},
+				ln.Close,
+			))
+			return nil
+		}),
+	)
+
+	ctx := context.Background()
+	if err := app.Start(ctx); err != nil {
+		return 1
+	}
+	select {
+	case <-served:
+	case <-time.After(10 * time.Second):
+	}
+	if err := app.Stop(ctx); err != nil {
+		return 1
+	}
+	return 0
+}
+
+func TestUpgrade(t *testing.T) {
+	// These tests can't run in parallel because they set the environment.
+
+	if runtime.GOOS == "windows" || runtime.GOOS == "js" {
+		t.Skipf("Upgrade is not supported on %v", runtime.GOOS)
+	}
+
+	// newApp builds an application listening on a random local port,
+	// returning the address of the port.
+	newApp := func(t *testing.T) (*App, string) {
+		var addr string
+		app := New(
+			NopLogger,
+			Invoke(func(lc Lifecycle, ls Listeners) error {
+				ln, err := ls.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					return err
+				}
+				addr = ln.Addr().String()
+				lc.Append(StopHook(ln.Close))
+				return nil
+			}),
+		)
+		require.NoError(t, app.Err())
+		return app, addr
+	}
+
+	t.Run("hands off listeners", func(t *testing.T) {
+		t.Setenv(_upgradeChildEnv, "serve")
+
+		app, addr := newApp(t)
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		done := app.Done()
+
+		require.NoError(t, app.Upgrade(ctx))
+		<-done
+		assert.Equal(t, StateStopped, app.State())
+
+		// We closed our copy of the listener, so only the new process
+		// can accept this connection.
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		defer conn.Close()
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+		msg, err := bufio.NewReader(conn).ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "child\n", msg)
+	})
+
+	t.Run("new process fails", func(t *testing.T) {
+		t.Setenv(_upgradeChildEnv, "fail")
+
+		app, addr := newApp(t)
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		defer app.Stop(ctx)
+
+		err := app.Upgrade(ctx)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "upgrade failed: new process exited before it was ready")
+		assert.Equal(t, StateStarted, app.State())
+
+		// We're still listening.
+		conn, err := net.Dial("tcp", addr)
+		require.NoError(t, err)
+		conn.Close()
+	})
+
+	t.Run("not started", func(t *testing.T) {
+		app, _ := newApp(t)
+		err := app.Upgrade(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "attempted to upgrade application when in state: stopped")
+	})
+}
+
+func TestListeners(t *testing.T) {
+	t.Parallel()
+
+	var ln net.Listener
+	app := New(
+		NopLogger,
+		Invoke(func(lc Lifecycle, ls Listeners) (err error) {
+			ln, err = ls.Listen("tcp", "127.0.0.1:0")
+			return err
+		}),
+	)
+	require.NoError(t, app.Err())
+	defer ln.Close()
+
+	conn, err := net.Dial("tcp", ln.Addr().String())
+	require.NoError(t, err)
+	conn.Close()
+}
@@ -132,6 +132,11 @@ func (m *module) build(app *App, root *dig.Container) {
 }
 
 func (m *module) provideAll() {
+	if m.parent != nil {
+		end := m.app.tracer.start("module", m.name, "")
+		defer end(nil)
+	}
+
 	for _, p := range m.provides {
 		m.provide(p)
 	}
@@ -147,9 +152,26 @@ func (m *module) provide(p provide) {
 	}
 
 	var info dig.ProvideInfo
-	if err := runProvide(m.scope, p, dig.FillProvideInfo(&info), dig.Export(!p.Private)); err != nil {
+
+	// Track which constructor appends each lifecycle hook.
+	node := &constructorNode{app: m.app, module: m, provide: p}
+
+	name, kind := p.describe()
+	r := &runReporter{module: m, name: name, kind: kind, node: node}
+	opts := []dig.ProvideOption{
+		dig.FillProvideInfo(&info),
+		dig.Export(!p.Private),
+		dig.WithProviderBeforeCallback(r.before),
+		dig.WithProviderCallback(r.after),
+	}
+
+	end := m.app.tracer.start(kind, name, m.name)
+	if err := runProvide(m.scope, p, opts...); err != nil {
 		m.app.err = err
+	} else {
+		node.register(info)
 	}
+	end(m.app.err)
 	var ev fxevent.Event
 	switch {
 	case p.IsSupply:
@@ -176,6 +198,15 @@ func (m *module) provide(p provide) {
 	m.log.LogEvent(ev)
 }
 
+// path returns the names of this module and the modules enclosing it,
+// outermost first. It is empty for the root module.
+func (m *module) path() []string {
+	if m.parent == nil {
+		return nil
+	}
+	return append(m.parent.path(), m.name)
+}
+
 // Constructs custom loggers for all modules in the tree
 func (m *module) constructAllCustomLoggers() {
 	if m.logConstructor != nil {
@@ -221,7 +252,12 @@ func (m *module) constructCustomLogger(buffer *logBuffer) (err error) {
 	})
 }
 
-func (m *module) executeInvokes() error {
+func (m *module) executeInvokes() (err error) {
+	if m.parent != nil {
+		end := m.app.tracer.start("module", m.name, "")
+		defer func() { end(err) }()
+	}
+
 	for _, m := range m.modules {
 		if err := m.executeInvokes(); err != nil {
 			return err
@@ -243,7 +279,12 @@ func (m *module) executeInvoke(i invoke) (err error) {
 		FunctionName: fnName,
 		ModuleName:   m.name,
 	})
+	prevModule := m.app.invokingModule
+	m.app.invokingModule = m.name
+	end := m.app.tracer.start("invoke", fnName, m.name)
 	err = runInvoke(m.scope, i)
+	end(err)
+	m.app.invokingModule = prevModule
 	m.log.LogEvent(&fxevent.Invoked{
 		FunctionName: fnName,
 		ModuleName:   m.name,
@@ -254,9 +295,24 @@ func (m *module) executeInvoke(i invoke) (err error) {
 }
 
 func (m *module) decorate() (err error) {
+	if m.parent != nil {
+		end := m.app.tracer.start("module", m.name, "")
+		defer func() { end(err) }()
+	}
+
 	for _, decorator := range m.decorators {
 		var info dig.DecorateInfo
-		err := runDecorator(m.scope, decorator, dig.FillDecorateInfo(&info))
+		name, kind := decorator.describe()
+		r := &runReporter{module: m, name: name, kind: kind}
+		opts := []dig.DecorateOption{
+			dig.FillDecorateInfo(&info),
+			dig.WithDecoratorBeforeCallback(r.before),
+			dig.WithDecoratorCallback(r.after),
+		}
+
+		end := m.app.tracer.start(kind, name, m.name)
+		err := runDecorator(m.scope, decorator, opts...)
+		end(err)
 		outputNames := make([]string, len(info.Outputs))
 		for i, o := range info.Outputs {
 			outputNames[i] = o.String()
@@ -280,6 +336,13 @@ func (m *module) decorate() (err error) {
 		if err != nil {
 			return err
 		}
+		m.app.decorators = append(m.app.decorators, &decoratorNode{
+			module:    m,
+			decorator: decorator,
+			info:      info,
+			inputs:    inputKeys(info.Inputs),
+			outputs:   decoratedKeys(info.Outputs),
+		})
 	}
 	for _, m := range m.modules {
 		if err := m.decorate(); err != nil {
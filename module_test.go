@@ -261,16 +261,16 @@ func TestModuleSuccess(t *testing.T) {
 				desc:           "custom logger for module",
 				giveWithLogger: fx.NopLogger,
 				wantEvents: []string{
-					"Supplied", "Provided", "Provided", "Provided",
-					"LoggerInitialized", "Invoking", "Invoked",
+					"Supplied", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
+					"Run", "LoggerInitialized", "Invoking", "Invoked",
 				},
 			},
 			{
 				desc:           "Not using a custom logger for module defaults to app logger",
 				giveWithLogger: fx.Options(),
 				wantEvents: []string{
-					"Supplied", "Provided", "Provided", "Provided", "Provided",
-					"LoggerInitialized", "Invoking", "Invoked", "Invoking", "Invoked",
+					"Supplied", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
+					"Run", "LoggerInitialized", "Invoking", "Run", "Invoked", "Invoking", "Invoked",
 				},
 			},
 		}
@@ -310,7 +310,7 @@ func TestModuleSuccess(t *testing.T) {
 
 				require.NoError(t, app.Err())
 
-				assert.Equal(t, []string{"Started", "Stopped"}, spy.EventTypes())
+				assert.Equal(t, []string{"StateChanged", "StateChanged", "Started", "StateChanged", "StateChanged", "Stopped"}, spy.EventTypes())
 			})
 		}
 	})
@@ -354,11 +354,11 @@ func TestModuleSuccess(t *testing.T) {
 
 		assert.Equal(t, []string{
 			"Provided", "Supplied", "Replaced",
-			"LoggerInitialized", "Invoking", "Invoked",
+			"Run", "Run", "LoggerInitialized", "Invoking", "Run", "Invoked",
 		}, moduleSpy.EventTypes())
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized", "Invoking", "Invoked",
 		}, appSpy.EventTypes())
 
@@ -369,7 +369,7 @@ func TestModuleSuccess(t *testing.T) {
 
 		require.NoError(t, app.Err())
 
-		assert.Equal(t, []string{"Started", "Stopped"}, appSpy.EventTypes())
+		assert.Equal(t, []string{"StateChanged", "StateChanged", "Started", "StateChanged", "StateChanged", "Stopped"}, appSpy.EventTypes())
 		assert.Empty(t, moduleSpy.EventTypes())
 	})
 
@@ -413,13 +413,13 @@ func TestModuleSuccess(t *testing.T) {
 		)
 
 		assert.Equal(t, []string{
-			"Supplied", "Provided", "Replaced", "LoggerInitialized",
+			"Supplied", "Provided", "Replaced", "Run", "Run", "LoggerInitialized",
 			//Invoke logged twice, once from child and another from grandchild
-			"Invoking", "Invoked", "Invoking", "Invoked",
+			"Invoking", "Run", "Invoked", "Invoking", "Invoked",
 		}, childSpy.EventTypes(), "events from grandchild also logged in child logger")
 
 		assert.Equal(t, []string{
-			"Provided", "Provided", "Provided",
+			"Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
 			"LoggerInitialized", "Invoking", "Invoked",
 		}, appSpy.EventTypes(), "events from modules do not appear in app logger")
 
@@ -430,7 +430,7 @@ func TestModuleSuccess(t *testing.T) {
 
 		require.NoError(t, app.Err())
 
-		assert.Equal(t, []string{"Started", "Stopped"}, appSpy.EventTypes())
+		assert.Equal(t, []string{"StateChanged", "StateChanged", "Started", "StateChanged", "StateChanged", "Stopped"}, appSpy.EventTypes())
 		assert.Empty(t, childSpy.EventTypes())
 	})
 }
@@ -649,7 +649,7 @@ func TestModuleFailures(t *testing.T) {
 					"must provide constructor function, got  (type *bytes.Buffer)",
 				},
 				wantEvents: []string{
-					"Supplied", "Provided", "LoggerInitialized",
+					"Supplied", "Provided", "Run", "LoggerInitialized",
 				},
 			},
 			{
@@ -660,8 +660,8 @@ func TestModuleFailures(t *testing.T) {
 				giveAppOpts:     spyAsLogger,
 				wantErrContains: []string{"error building logger"},
 				wantEvents: []string{
-					"Supplied", "Provided", "Provided", "Provided",
-					"LoggerInitialized", "Provided", "LoggerInitialized",
+					"Supplied", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
+					"Run", "LoggerInitialized", "Provided", "LoggerInitialized",
 				},
 			},
 			{
@@ -678,8 +678,8 @@ func TestModuleFailures(t *testing.T) {
 				giveAppOpts:     spyAsLogger,
 				wantErrContains: []string{"error building logger dependency"},
 				wantEvents: []string{
-					"Supplied", "Provided", "Provided", "Provided",
-					"LoggerInitialized", "Provided", "Provided", "LoggerInitialized",
+					"Supplied", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
+					"Run", "LoggerInitialized", "Provided", "Provided", "Run", "LoggerInitialized",
 				},
 			},
 			{
@@ -690,8 +690,8 @@ func TestModuleFailures(t *testing.T) {
 					"fx.WithLogger", "from:", "Failed",
 				},
 				wantEvents: []string{
-					"Supplied", "Provided", "Provided", "Provided",
-					"LoggerInitialized", "Provided", "LoggerInitialized",
+					"Supplied", "Provided", "Provided", "Provided", "Provided", "Provided", "Provided",
+					"Run", "LoggerInitialized", "Provided", "LoggerInitialized",
 				},
 			},
 		}
@@ -0,0 +1,175 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// LifecycleReport describes how long an application took to start or stop,
+// and how long each lifecycle hook run during that time took.
+//
+// Use sort.Sort on the Hooks field to find the slowest hooks.
+type LifecycleReport struct {
+	// Hooks lists the hooks that ran, in the order they finished.
+	Hooks HookReports `json:"hooks"`
+
+	// Total is how long the application took to start or stop, including
+	// time not spent in hooks.
+	Total time.Duration `json:"total_ns"`
+}
+
+// StartupReport returns a report of the most recent attempt to start the
+// application with Start, Run, or Restart. Hooks that failed are included.
+//
+// The report is empty if the application hasn't been started.
+func (app *App) StartupReport() LifecycleReport {
+	return newLifecycleReport(app.lifecycle.StartRecords())
+}
+
+// ShutdownReport returns a report of the most recent attempt to stop the
+// application with Stop, Run, or Restart, including stops caused by a
+// failed start rolling back. Hooks that failed are included.
+//
+// The report is empty if the application hasn't been stopped.
+func (app *App) ShutdownReport() LifecycleReport {
+	return newLifecycleReport(app.lifecycle.StopRecords())
+}
+
+func newLifecycleReport(records lifecycle.HookRecords, total time.Duration) LifecycleReport {
+	hooks := make(HookReports, len(records))
+	for i, r := range records {
+		hooks[i] = HookReport{
+			Method:       r.Method,
+			FunctionName: r.FuncName,
+			CallerName:   r.CallerFrame.Function,
+			CallerFile:   r.CallerFrame.File,
+			CallerLine:   r.CallerFrame.Line,
+			ModuleName:   r.Module,
+			Runtime:      r.Runtime,
+			Err:          r.Err,
+		}
+	}
+	return LifecycleReport{Hooks: hooks, Total: total}
+}
+
+// String renders the report as human-readable text, one hook per line.
+func (r LifecycleReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d hooks ran in %v", len(r.Hooks), r.Total)
+	for _, h := range r.Hooks {
+		fmt.Fprintf(&b, "\n\t%v", h)
+	}
+	return b.String()
+}
+
+// HookReport describes a single run of a lifecycle hook.
+type HookReport struct {
+	// Method is the kind of hook that ran: one of "OnStart", "OnReady",
+	// "OnDrain" and "OnStop".
+	Method string
+
+	// FunctionName is the name of the hook function.
+	FunctionName string
+
+	// CallerName is the name of the function that appended the hook to
+	// the Lifecycle, and CallerFile and CallerLine identify where it did
+	// so.
+	CallerName string
+	CallerFile string
+	CallerLine int
+
+	// ModuleName is the name of the module that appended the hook, if
+	// any.
+	ModuleName string
+
+	// Runtime is how long the hook ran.
+	Runtime time.Duration
+
+	// Err is non-nil if the hook failed.
+	Err error
+}
+
+// String renders the hook report as a single line of text.
+func (h HookReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v %s %s appended by %s", h.Runtime, h.Method, h.FunctionName, h.CallerName)
+	if len(h.CallerFile) > 0 {
+		fmt.Fprintf(&b, " (%s:%d)", h.CallerFile, h.CallerLine)
+	}
+	if len(h.ModuleName) > 0 {
+		fmt.Fprintf(&b, " from module %q", h.ModuleName)
+	}
+	if h.Err != nil {
+		fmt.Fprintf(&b, " failed: %v", h.Err)
+	}
+	return b.String()
+}
+
+// MarshalJSON renders the hook report as a JSON object. Runtime is
+// reported in nanoseconds, and Err as its message.
+func (h HookReport) MarshalJSON() ([]byte, error) {
+	var errMsg string
+	if h.Err != nil {
+		errMsg = h.Err.Error()
+	}
+	return json.Marshal(struct {
+		Method       string        `json:"method"`
+		FunctionName string        `json:"function"`
+		CallerName   string        `json:"caller"`
+		CallerFile   string        `json:"file,omitempty"`
+		CallerLine   int           `json:"line,omitempty"`
+		ModuleName   string        `json:"module,omitempty"`
+		Runtime      time.Duration `json:"runtime_ns"`
+		Err          string        `json:"error,omitempty"`
+	}{
+		Method:       h.Method,
+		FunctionName: h.FunctionName,
+		CallerName:   h.CallerName,
+		CallerFile:   h.CallerFile,
+		CallerLine:   h.CallerLine,
+		ModuleName:   h.ModuleName,
+		Runtime:      h.Runtime,
+		Err:          errMsg,
+	})
+}
+
+// HookReports is a list of hook reports. It implements sort.Interface,
+// ordering the slowest hooks first.
+type HookReports []HookReport
+
+func (rs HookReports) Len() int {
+	return len(rs)
+}
+
+func (rs HookReports) Less(i, j int) bool {
+	// Sort by runtime, greater ones at top.
+	return rs[i].Runtime > rs[j].Runtime
+}
+
+func (rs HookReports) Swap(i, j int) {
+	rs[i], rs[j] = rs[j], rs[i]
+}
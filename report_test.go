@@ -0,0 +1,157 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+)
+
+func TestLifecycleReports(t *testing.T) {
+	t.Parallel()
+
+	type Server struct{}
+
+	newApp := func(t *testing.T, failStop bool) (*fx.App, *clock.Mock) {
+		mockClock := clock.NewMock()
+		sleep := func(d time.Duration) func(context.Context) error {
+			return func(context.Context) error {
+				mockClock.Add(d)
+				return nil
+			}
+		}
+
+		app := fx.New(
+			fx.WithLogger(func() fxevent.Logger { return fxevent.NopLogger }),
+			fx.WithClock(mockClock),
+			fx.Module("http",
+				fx.Provide(func(lc fx.Lifecycle) *Server {
+					lc.Append(fx.Hook{
+						OnStart: sleep(time.Second),
+						OnStop: func(context.Context) error {
+							mockClock.Add(time.Millisecond)
+							if failStop {
+								return errors.New("great sadness")
+							}
+							return nil
+						},
+					})
+					return &Server{}
+				}),
+			),
+			fx.Invoke(func(lc fx.Lifecycle, _ *Server) {
+				lc.Append(fx.Hook{
+					OnStart: sleep(3 * time.Second),
+					OnReady: sleep(2 * time.Second),
+				})
+			}),
+		)
+		require.NoError(t, app.Err())
+		return app, mockClock
+	}
+
+	t.Run("Startup", func(t *testing.T) {
+		t.Parallel()
+
+		app, _ := newApp(t, false)
+		assert.Empty(t, app.StartupReport().Hooks, "report must be empty before start")
+
+		require.NoError(t, app.Start(context.Background()))
+		defer app.Stop(context.Background())
+
+		report := app.StartupReport()
+		assert.Equal(t, 6*time.Second, report.Total)
+		require.Len(t, report.Hooks, 3)
+
+		server := report.Hooks[0]
+		assert.Equal(t, "OnStart", server.Method)
+		assert.Equal(t, time.Second, server.Runtime)
+		assert.Equal(t, "http", server.ModuleName)
+		assert.Contains(t, server.CallerName, "TestLifecycleReports")
+		assert.NotEmpty(t, server.CallerFile)
+		assert.NotZero(t, server.CallerLine)
+
+		assert.Equal(t, "OnReady", report.Hooks[2].Method)
+		assert.Empty(t, report.Hooks[2].ModuleName)
+
+		sort.Sort(report.Hooks)
+		assert.Equal(t, []time.Duration{3 * time.Second, 2 * time.Second, time.Second}, []time.Duration{
+			report.Hooks[0].Runtime,
+			report.Hooks[1].Runtime,
+			report.Hooks[2].Runtime,
+		})
+	})
+
+	t.Run("Shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		app, _ := newApp(t, true)
+		require.NoError(t, app.Start(context.Background()))
+		require.Error(t, app.Stop(context.Background()))
+
+		report := app.ShutdownReport()
+		assert.Equal(t, time.Millisecond, report.Total)
+		require.Len(t, report.Hooks, 1)
+		assert.Equal(t, "OnStop", report.Hooks[0].Method)
+		assert.EqualError(t, report.Hooks[0].Err, "great sadness")
+
+		assert.Contains(t, report.String(), "1 hooks ran in 1ms")
+		assert.Contains(t, report.String(), `from module "http" failed: great sadness`)
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		t.Parallel()
+
+		app, _ := newApp(t, true)
+		require.NoError(t, app.Start(context.Background()))
+		require.Error(t, app.Stop(context.Background()))
+
+		b, err := json.Marshal(app.ShutdownReport())
+		require.NoError(t, err)
+
+		var got struct {
+			Total int64 `json:"total_ns"`
+			Hooks []struct {
+				Method  string `json:"method"`
+				Module  string `json:"module"`
+				Runtime int64  `json:"runtime_ns"`
+				Error   string `json:"error"`
+			} `json:"hooks"`
+		}
+		require.NoError(t, json.Unmarshal(b, &got))
+		assert.Equal(t, int64(time.Millisecond), got.Total)
+		require.Len(t, got.Hooks, 1)
+		assert.Equal(t, "OnStop", got.Hooks[0].Method)
+		assert.Equal(t, "http", got.Hooks[0].Module)
+		assert.Equal(t, int64(time.Millisecond), got.Hooks[0].Runtime)
+		assert.Equal(t, "great sadness", got.Hooks[0].Error)
+	})
+}
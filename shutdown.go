@@ -55,6 +55,27 @@ func ExitCode(code int) ShutdownOption {
 	return exitCodeOption(code)
 }
 
+type shutdownCauseOption struct{ err error }
+
+func (o shutdownCauseOption) apply(s *shutdowner) {
+	s.cause = o.err
+}
+
+var _ ShutdownOption = shutdownCauseOption{}
+
+// ShutdownCause is a [ShutdownOption] that may be passed to the Shutdown
+// method of the [Shutdowner] interface to record the error that triggered
+// the shutdown. The error will be broadcasted to any receiver waiting on a
+// [ShutdownSignal] from the [Wait] method, and logged in the
+// fxevent.Stopping event emitted by [App.Run].
+//
+//	if err := srv.Serve(ln); err != nil {
+//		shutdowner.Shutdown(fx.ShutdownCause(err), fx.ExitCode(2))
+//	}
+func ShutdownCause(err error) ShutdownOption {
+	return shutdownCauseOption{err: err}
+}
+
 type shutdownTimeoutOption time.Duration
 
 func (to shutdownTimeoutOption) apply(s *shutdowner) {
@@ -74,14 +95,18 @@ func ShutdownTimeout(timeout time.Duration) ShutdownOption {
 type shutdowner struct {
 	app             *App
 	exitCode        int
+	cause           error
 	shutdownTimeout time.Duration
 }
 
 // Shutdown broadcasts a signal to all of the application's Done channels
-// and begins the Stop process. Applications can be shut down only after they
-// have finished starting up.
-// In practice this means Shutdowner.Shutdown should not be called from an
-// fx.Invoke, but from a fx.Lifecycle.OnStart hook.
+// and begins the Stop process.
+//
+// Shutdown may also be called before the application starts, for example
+// from a constructor or an fx.Invoke in a one-shot command line program. The
+// request is recorded, App.Start then returns without executing any hooks,
+// and the signal is delivered to Done and Wait channels, including those
+// created afterwards.
 func (s *shutdowner) Shutdown(opts ...ShutdownOption) error {
 	for _, opt := range opts {
 		opt.apply(s)
@@ -103,6 +128,7 @@ func (s *shutdowner) Shutdown(opts ...ShutdownOption) error {
 	return s.app.receivers.Broadcast(ShutdownSignal{
 		Signal:   _sigTERM,
 		ExitCode: s.exitCode,
+		Cause:    s.cause,
 	})
 }
 
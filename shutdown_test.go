@@ -22,6 +22,7 @@ package fx_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"testing"
@@ -30,7 +31,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
 	"go.uber.org/fx/fxtest"
+	"go.uber.org/fx/internal/fxlog"
 )
 
 func TestShutdown(t *testing.T) {
@@ -107,6 +110,23 @@ func TestShutdown(t *testing.T) {
 		require.Equal(t, 2, wait.ExitCode)
 	})
 
+	t.Run("with cause", func(t *testing.T) {
+		t.Parallel()
+		var s fx.Shutdowner
+		app := fxtest.New(
+			t,
+			fx.Populate(&s),
+		)
+
+		require.NoError(t, app.Start(context.Background()), "error starting app")
+		errCause := errors.New("great sadness")
+		assert.NoError(t, s.Shutdown(fx.ShutdownCause(errCause)), "error in app shutdown")
+		wait := <-app.Wait()
+		defer app.Stop(context.Background())
+		require.Equal(t, errCause, wait.Cause)
+		require.Zero(t, wait.ExitCode)
+	})
+
 	t.Run("with exit code and multiple Wait", func(t *testing.T) {
 		t.Parallel()
 		var s fx.Shutdowner
@@ -130,6 +150,107 @@ func TestShutdown(t *testing.T) {
 	})
 }
 
+func TestShutdownBeforeStart(t *testing.T) {
+	t.Parallel()
+
+	// newApp builds an application with an OnStart hook that records
+	// whether it ran.
+	newApp := func(opts ...fx.Option) (*fx.App, *fxlog.Spy, *bool) {
+		var started bool
+		spy := new(fxlog.Spy)
+		app := fx.New(append([]fx.Option{
+			fx.WithLogger(func() fxevent.Logger { return spy }),
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.StartHook(func() { started = true }))
+			}),
+		}, opts...)...)
+		return app, spy, &started
+	}
+
+	t.Run("from Invoke", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy, started := newApp(
+			fx.Invoke(func(s fx.Shutdowner) error {
+				return s.Shutdown(fx.ExitCode(2))
+			}),
+		)
+		require.NoError(t, app.Err())
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		assert.False(t, *started, "OnStart hooks must not run")
+		assert.Equal(t, fx.StateStopped, app.State())
+
+		sig := <-app.Wait()
+		assert.Equal(t, 2, sig.ExitCode)
+		assert.NotNil(t, <-app.Done())
+		require.NoError(t, app.Stop(ctx))
+
+		assert.Len(t, spy.Events().SelectByTypeName("StartSkipped"), 1)
+		assert.Empty(t, spy.Events().SelectByTypeName("Started"))
+	})
+
+	t.Run("from constructor", func(t *testing.T) {
+		t.Parallel()
+
+		type A struct{}
+		app, _, started := newApp(
+			fx.Provide(func(s fx.Shutdowner) (*A, error) {
+				return &A{}, s.Shutdown(fx.ExitCode(3))
+			}),
+			fx.Invoke(func(*A) {}),
+		)
+
+		err := app.RunContext(context.Background())
+		assert.EqualError(t, err, "shut down with exit code 3")
+		assert.False(t, *started, "OnStart hooks must not run")
+	})
+
+	t.Run("start again", func(t *testing.T) {
+		t.Parallel()
+
+		app, _, started := newApp(
+			fx.Invoke(func(s fx.Shutdowner) error { return s.Shutdown() }),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		require.NoError(t, app.Stop(ctx))
+		assert.False(t, *started)
+
+		// The shutdown request applied to the first start only.
+		require.NoError(t, app.Start(ctx))
+		assert.True(t, *started)
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	t.Run("during Start", func(t *testing.T) {
+		t.Parallel()
+
+		var secondStarted bool
+		app, spy, started := newApp(
+			fx.Invoke(func(s fx.Shutdowner, lc fx.Lifecycle) {
+				lc.Append(fx.StartHook(func() error {
+					return s.Shutdown(fx.ExitCode(4))
+				}))
+				lc.Append(fx.StartHook(func() { secondStarted = true }))
+			}),
+		)
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		defer app.Stop(ctx)
+
+		assert.True(t, *started)
+		assert.True(t, secondStarted, "Start must run the remaining hooks")
+		assert.Equal(t, fx.StateStarted, app.State())
+		assert.Equal(t, 4, (<-app.Wait()).ExitCode,
+			"signal must be delivered to channels created after Start")
+		assert.Empty(t, spy.Events().SelectByTypeName("StartSkipped"))
+	})
+}
+
 func TestDataRace(t *testing.T) {
 	t.Parallel()
 
@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 )
 
@@ -34,9 +35,13 @@ import (
 //
 // Should the application receive an operating system signal,
 // the Signal field will be populated with the received os.Signal.
+//
+// Should a user call the Shutdown method with a ShutdownCause, the error
+// that triggered the shutdown will be populated in the Cause field.
 type ShutdownSignal struct {
 	Signal   os.Signal
 	ExitCode int
+	Cause    error
 }
 
 // String will render a ShutdownSignal type as a string suitable for printing.
@@ -44,10 +49,94 @@ func (sig ShutdownSignal) String() string {
 	return fmt.Sprintf("%v", sig.Signal)
 }
 
+// ShutdownSignals specifies the operating system signals that make the
+// application shut down, replacing the default of SIGINT and SIGTERM. For
+// example, to also shut down on SIGQUIT,
+//
+//	fx.ShutdownSignals(syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+//
+// Signals left out of the set keep their default behavior as documented in
+// os/signal. Use signal.Ignore to ignore them instead.
+func ShutdownSignals(sigs ...os.Signal) Option {
+	return shutdownSignalsOption(sigs)
+}
+
+type shutdownSignalsOption []os.Signal
+
+func (o shutdownSignalsOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.ShutdownSignals Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else if len(o) == 0 {
+		m.app.err = fmt.Errorf("fx.ShutdownSignals requires at least one signal; " +
+			"use fx.NoSignalHandling to disable signal handling")
+	} else {
+		m.app.receivers.sigs = append([]os.Signal(nil), o...)
+	}
+}
+
+func (o shutdownSignalsOption) String() string {
+	names := make([]string, len(o))
+	for i, sig := range o {
+		names[i] = sig.String()
+	}
+	return fmt.Sprintf("fx.ShutdownSignals(%s)", strings.Join(names, ", "))
+}
+
+// NoSignalHandling stops the application from listening for operating system
+// signals. Use it when the process embedding the application handles signals
+// itself. The application can still be shut down with a Shutdowner, or by
+// calling Stop directly.
+func NoSignalHandling() Option {
+	return noSignalHandlingOption{}
+}
+
+type noSignalHandlingOption struct{}
+
+func (noSignalHandlingOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.NoSignalHandling Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.receivers.sigs = nil
+		m.app.receivers.reloadSigs = nil
+	}
+}
+
+func (noSignalHandlingOption) String() string {
+	return "fx.NoSignalHandling()"
+}
+
+// ForceExitOnSecondSignal keeps the application listening for shutdown
+// signals after it was asked to shut down. If another signal arrives before
+// the application has stopped, for example because an OnStop hook hangs, the
+// application logs the hook that was running and exits immediately with
+// ForceExitCode, without waiting for the remaining hooks.
+func ForceExitOnSecondSignal() Option {
+	return forceExitOnSecondSignalOption{}
+}
+
+type forceExitOnSecondSignalOption struct{}
+
+func (forceExitOnSecondSignalOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.ForceExitOnSecondSignal Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.receivers.forceExit = m.app.forceExit
+	}
+}
+
+func (forceExitOnSecondSignalOption) String() string {
+	return "fx.ForceExitOnSecondSignal()"
+}
+
 func newSignalReceivers() signalReceivers {
 	return signalReceivers{
-		notify:  signal.Notify,
-		signals: make(chan os.Signal, 1),
+		notify:     signal.Notify,
+		signals:    make(chan os.Signal, 1),
+		sigs:       []os.Signal{os.Interrupt, _sigINT, _sigTERM},
+		reloadSigs: _reloadSignals,
 	}
 }
 
@@ -66,12 +155,30 @@ type signalReceivers struct {
 	// this stub allows us to unit test signal relay functionality
 	notify func(c chan<- os.Signal, sig ...os.Signal)
 
+	// the signals relayed to Done and Wait; if empty, we don't listen for
+	// signals at all
+	sigs []os.Signal
+
+	// the signals that trigger reload instead, unless they're also among
+	// sigs; we only listen for them if reload is set
+	reloadSigs []os.Signal
+	reload     func(os.Signal)
+
+	// if set, we keep relaying after a shutdown signal, and call this
+	// instead if a signal arrives once shutdown was requested
+	forceExit func(os.Signal)
+
 	// last will contain a pointer to the last ShutdownSignal received, or
 	// nil if none, if a new channel is created by Wait or Done, this last
 	// signal will be immediately written to, this allows Wait or Done state
 	// to be read after application stop
 	last *ShutdownSignal
 
+	// whether last was received while the relayer wasn't running, i.e.
+	// before or while the application started; such signals survive the
+	// next Start
+	early bool
+
 	// contains channels created by Done
 	done []chan os.Signal
 
@@ -79,19 +186,85 @@ type signalReceivers struct {
 	wait []chan ShutdownSignal
 }
 
-func (recv *signalReceivers) relayer(ctx context.Context) {
+func (recv *signalReceivers) relayer(ctx context.Context, reload func(os.Signal)) {
 	defer func() {
 		recv.finished <- struct{}{}
 	}()
 
-	select {
-	case <-recv.shutdown:
-		return
-	case signal := <-recv.signals:
-		recv.Broadcast(ShutdownSignal{
-			Signal: signal,
-		})
+	for {
+		select {
+		case <-recv.shutdown:
+			return
+		case signal := <-recv.signals:
+			// Reload on a separate goroutine so that we can still
+			// shut down while OnReload hooks run.
+			if reload != nil && recv.isReloadSignal(signal) {
+				go reload(signal)
+				continue
+			}
+			if recv.forceExit == nil {
+				recv.Broadcast(ShutdownSignal{
+					Signal: signal,
+				})
+				return
+			}
+			if recv.shuttingDown() {
+				recv.forceExit(signal)
+				continue
+			}
+			recv.Broadcast(ShutdownSignal{
+				Signal: signal,
+			})
+		}
+	}
+}
+
+// isReloadSignal reports whether the given signal should trigger a reload
+// rather than a shutdown.
+func (recv *signalReceivers) isReloadSignal(signal os.Signal) bool {
+	for _, sig := range recv.sigs {
+		if sig == signal {
+			return false
+		}
+	}
+	for _, sig := range recv.reloadSigs {
+		if sig == signal {
+			return true
+		}
+	}
+	return false
+}
+
+// shuttingDown reports whether a shutdown signal was already broadcast since
+// the relayer was started.
+func (recv *signalReceivers) shuttingDown() bool {
+	recv.m.Lock()
+	defer recv.m.Unlock()
+
+	return recv.last != nil
+}
+
+// TakeEarly returns the shutdown signal received before the application
+// started, if any, and forgets that it was received early.
+func (recv *signalReceivers) TakeEarly() (ShutdownSignal, bool) {
+	recv.m.Lock()
+	defer recv.m.Unlock()
+
+	if !recv.early {
+		return ShutdownSignal{}, false
 	}
+	recv.early = false
+	return *recv.last, true
+}
+
+// SetReload specifies the function to call when a reload signal is received
+// while the relayer is running. If nil, we don't listen for reload signals.
+// It takes effect the next time the relayer is started.
+func (recv *signalReceivers) SetReload(reload func(os.Signal)) {
+	recv.m.Lock()
+	defer recv.m.Unlock()
+
+	recv.reload = reload
 }
 
 // running returns true if the the signal relay go-routine is running.
@@ -109,11 +282,22 @@ func (recv *signalReceivers) Start(ctx context.Context) {
 		return
 	}
 
-	recv.last = nil
+	// Keep a shutdown requested while the application was starting, but
+	// forget the one that stopped it last time.
+	if !recv.early {
+		recv.last = nil
+	}
+	recv.early = false
 	recv.finished = make(chan struct{}, 1)
 	recv.shutdown = make(chan struct{}, 1)
-	recv.notify(recv.signals, os.Interrupt, _sigINT, _sigTERM)
-	go recv.relayer(ctx)
+	sigs := recv.sigs
+	if recv.reload != nil {
+		sigs = append(sigs[:len(sigs):len(sigs)], recv.reloadSigs...)
+	}
+	if len(sigs) > 0 {
+		recv.notify(recv.signals, sigs...)
+	}
+	go recv.relayer(ctx, recv.reload)
 }
 
 func (recv *signalReceivers) Stop(ctx context.Context) error {
@@ -176,6 +360,7 @@ func (recv *signalReceivers) Broadcast(signal ShutdownSignal) error {
 	defer recv.m.Unlock()
 
 	recv.last = &signal
+	recv.early = !recv.running()
 
 	channels, unsent := recv.broadcast(
 		signal,
@@ -28,6 +28,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxlog"
 )
 
 func assertUnsentSignalError(
@@ -115,3 +117,201 @@ func TestSignal(t *testing.T) {
 		})
 	})
 }
+
+func TestShutdownSignals(t *testing.T) {
+	t.Parallel()
+
+	// notified starts app and returns the signals it asked to be notified
+	// of, or nil if it didn't ask to be notified at all.
+	notified := func(t *testing.T, opts ...Option) []os.Signal {
+		app := New(append(opts, NopLogger)...)
+		require.NoError(t, app.Err())
+
+		var sigs []os.Signal
+		app.receivers.notify = func(_ chan<- os.Signal, s ...os.Signal) {
+			sigs = s
+		}
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		require.NoError(t, app.Stop(ctx))
+		return sigs
+	}
+
+	t.Run("default", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, []os.Signal{os.Interrupt, _sigINT, _sigTERM}, notified(t))
+	})
+
+	t.Run("custom", func(t *testing.T) {
+		t.Parallel()
+
+		sigs := notified(t, ShutdownSignals(syscall.SIGTERM, syscall.SIGQUIT))
+		assert.Equal(t, []os.Signal{syscall.SIGTERM, syscall.SIGQUIT}, sigs)
+	})
+
+	t.Run("no signal handling", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Nil(t, notified(t, NoSignalHandling()))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		t.Parallel()
+
+		err := New(NopLogger, ShutdownSignals()).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.ShutdownSignals requires at least one signal")
+	})
+
+	t.Run("in module", func(t *testing.T) {
+		t.Parallel()
+
+		err := New(NopLogger, Module("foo", NoSignalHandling())).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.NoSignalHandling Option should be passed to top-level App")
+
+		err = New(NopLogger, Module("foo", ShutdownSignals(syscall.SIGTERM))).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.ShutdownSignals Option should be passed to top-level App")
+	})
+}
+
+func TestReloadSignal(t *testing.T) {
+	t.Parallel()
+
+	if len(_reloadSignals) == 0 {
+		t.Skip("no reload signals on this platform")
+	}
+	reloadSig := _reloadSignals[0]
+
+	// start starts app with a stubbed notify, returning the signals it
+	// asked to be notified of and a channel to send signals on.
+	start := func(t *testing.T, app *App) ([]os.Signal, chan<- os.Signal) {
+		require.NoError(t, app.Err())
+
+		stub := make(chan os.Signal)
+		var sigs []os.Signal
+		app.receivers.notify = func(ch chan<- os.Signal, s ...os.Signal) {
+			sigs = s
+			go func() {
+				for sig := range stub {
+					ch <- sig
+				}
+			}()
+		}
+		require.NoError(t, app.Start(context.Background()))
+		return sigs, stub
+	}
+
+	t.Run("reloads", func(t *testing.T) {
+		t.Parallel()
+
+		reloaded := make(chan struct{})
+		app := New(
+			NopLogger,
+			Invoke(func(lc Lifecycle) {
+				lc.Append(ReloadHook(func() { close(reloaded) }))
+			}),
+		)
+		sigs, stub := start(t, app)
+		defer close(stub)
+
+		assert.Contains(t, sigs, reloadSig)
+		done := app.Done()
+		stub <- reloadSig
+		<-reloaded
+
+		stub <- _sigTERM
+		assert.Equal(t, _sigTERM, <-done)
+		require.NoError(t, app.Stop(context.Background()))
+	})
+
+	t.Run("no reload hooks", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(NopLogger)
+		sigs, stub := start(t, app)
+		defer close(stub)
+
+		assert.NotContains(t, sigs, reloadSig)
+		require.NoError(t, app.Stop(context.Background()))
+	})
+
+	t.Run("shutdown signal wins", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(
+			NopLogger,
+			ShutdownSignals(reloadSig),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(ReloadHook(func() {
+					assert.Fail(t, "must not reload")
+				}))
+			}),
+		)
+		_, stub := start(t, app)
+		defer close(stub)
+
+		done := app.Done()
+		stub <- reloadSig
+		assert.Equal(t, reloadSig, <-done)
+		require.NoError(t, app.Stop(context.Background()))
+	})
+}
+
+func TestForceExitOnSecondSignal(t *testing.T) {
+	t.Parallel()
+
+	stopping := make(chan struct{})
+	release := make(chan struct{})
+	exited := make(chan int, 1)
+	spy := new(fxlog.Spy)
+	app := New(
+		WithLogger(func() fxevent.Logger { return spy }),
+		ForceExitOnSecondSignal(),
+		WithExit(func(code int) { exited <- code }),
+		Invoke(func(lc Lifecycle) {
+			lc.Append(StopHook(func() {
+				close(stopping)
+				<-release
+			}))
+		}),
+	)
+	require.NoError(t, app.Err())
+
+	stub := make(chan os.Signal)
+	defer close(stub)
+	app.receivers.notify = func(ch chan<- os.Signal, _ ...os.Signal) {
+		go func() {
+			for sig := range stub {
+				ch <- sig
+			}
+		}()
+	}
+
+	ctx := context.Background()
+	require.NoError(t, app.Start(ctx))
+	done := app.Done()
+
+	stub <- _sigTERM
+	assert.Equal(t, _sigTERM, <-done)
+	assert.Empty(t, exited, "first signal must not force an exit")
+
+	stopped := make(chan error, 1)
+	go func() { stopped <- app.Stop(ctx) }()
+	<-stopping
+
+	stub <- _sigTERM
+	assert.Equal(t, ForceExitCode, <-exited)
+
+	events := spy.Events().SelectByTypeName("ForceExiting")
+	require.Len(t, events, 1)
+	e := events[0].(*fxevent.ForceExiting)
+	assert.Equal(t, _sigTERM, e.Signal)
+	assert.Contains(t, e.CallerName, "TestForceExitOnSecondSignal")
+
+	close(release)
+	require.NoError(t, <-stopped)
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import "go.uber.org/fx/internal/lifecycle"
+
+// State describes where an application is in its lifecycle. Its String
+// method returns the names used in fxevent.StateChanged events.
+type State = lifecycle.State
+
+// States of an application. An application goes from StateStopped to
+// StateStarting when it's started. From there, it goes to StateStarted if
+// all OnStart and OnReady hooks succeed, or to StateIncompleteStart if one of
+// them fails. Stopping the application goes through StateStopping and back
+// to StateStopped.
+const (
+	StateStopped         = lifecycle.Stopped
+	StateStarting        = lifecycle.Starting
+	StateIncompleteStart = lifecycle.IncompleteStart
+	StateStarted         = lifecycle.Started
+	StateStopping        = lifecycle.Stopping
+)
+
+// State returns the current state of the application. It's safe to call
+// concurrently with Start, Stop, and Restart, for example from a readiness
+// probe.
+//
+// Every change of state is also reported to the application's fxevent.Logger
+// as an fxevent.StateChanged event.
+func (app *App) State() State {
+	return app.lifecycle.State()
+}
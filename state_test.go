@@ -0,0 +1,111 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxlog"
+)
+
+func TestAppState(t *testing.T) {
+	t.Parallel()
+
+	// stateChanges returns the transitions reported to spy as "from->to".
+	stateChanges := func(spy *fxlog.Spy) []string {
+		var changes []string
+		for _, e := range spy.Events().SelectByTypeName("StateChanged") {
+			ev := e.(*fxevent.StateChanged)
+			changes = append(changes, ev.From+"->"+ev.To)
+		}
+		return changes
+	}
+
+	t.Run("StartAndStop", func(t *testing.T) {
+		t.Parallel()
+
+		var app *fx.App
+		var duringStart, duringStop fx.State
+		spy := new(fxlog.Spy)
+		app = fx.New(
+			fx.WithLogger(func() fxevent.Logger { return spy }),
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.Hook{
+					OnStart: func(context.Context) error {
+						duringStart = app.State()
+						return nil
+					},
+					OnStop: func(context.Context) error {
+						duringStop = app.State()
+						return nil
+					},
+				})
+			}),
+		)
+		require.NoError(t, app.Err())
+		assert.Equal(t, fx.StateStopped, app.State())
+
+		require.NoError(t, app.Start(context.Background()))
+		assert.Equal(t, fx.StateStarting, duringStart)
+		assert.Equal(t, fx.StateStarted, app.State())
+
+		require.NoError(t, app.Stop(context.Background()))
+		assert.Equal(t, fx.StateStopping, duringStop)
+		assert.Equal(t, fx.StateStopped, app.State())
+
+		assert.Equal(t, []string{
+			"stopped->starting",
+			"starting->started",
+			"started->stopping",
+			"stopping->stopped",
+		}, stateChanges(spy))
+	})
+
+	t.Run("FailedStart", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		app := fx.New(
+			fx.WithLogger(func() fxevent.Logger { return spy }),
+			fx.Invoke(func(lc fx.Lifecycle) {
+				lc.Append(fx.StartHook(func() error {
+					return errors.New("great sadness")
+				}))
+			}),
+		)
+		require.NoError(t, app.Err())
+
+		require.Error(t, app.Start(context.Background()))
+		assert.Equal(t, fx.StateStopped, app.State())
+		assert.Equal(t, []string{
+			"stopped->starting",
+			"starting->incompleteStart",
+			"incompleteStart->stopping",
+			"stopping->stopped",
+		}, stateChanges(spy))
+	})
+}
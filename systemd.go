@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"os"
+
+	"go.uber.org/fx/internal/sdnotify"
+)
+
+// SystemdNotify makes the application report its state to systemd for
+// services with Type=notify, replacing hand-written sd_notify calls:
+//
+//   - READY=1 once Start succeeds, and again after each reload or restart;
+//   - STOPPING=1 when Stop begins stopping the application, whether it's
+//     called directly, by Run, or by Upgrade;
+//   - RELOADING=1 before the OnReload hooks run; and
+//   - WATCHDOG=1 at half the interval given by WATCHDOG_USEC, from the time
+//     the application is ready until Stop returns, if the service has
+//     WatchdogSec set.
+//
+// Notifications are sent over the datagram socket named by NOTIFY_SOCKET.
+// The option does nothing if NOTIFY_SOCKET is not set, so the same binary
+// runs unchanged outside systemd. Notifications that fail to send are
+// dropped; systemd's own timeouts then apply.
+//
+//	fx.New(
+//		fx.SystemdNotify(),
+//		...
+//	)
+func SystemdNotify() Option {
+	return systemdNotifyOption{}
+}
+
+type systemdNotifyOption struct{}
+
+func (systemdNotifyOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.SystemdNotify Option should be passed to top-level " +
+			"App, not to fx.Module")
+		return
+	}
+
+	notifier, err := sdnotify.FromEnv(os.Getenv)
+	if err != nil {
+		m.app.err = fmt.Errorf("fx.SystemdNotify: %w", err)
+		return
+	}
+	m.app.notifier = notifier
+}
+
+func (systemdNotifyOption) String() string {
+	return "fx.SystemdNotify()"
+}
+
+// sdNotify reports the given states to systemd if the SystemdNotify option
+// is in use.
+func (app *App) sdNotify(states ...string) {
+	// Failures are deliberately ignored; see SystemdNotify.
+	_ = app.notifier.Notify(states...)
+}
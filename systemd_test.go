@@ -0,0 +1,160 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+)
+
+func TestSystemdNotify(t *testing.T) {
+	// These tests can't run in parallel because they set the environment.
+
+	// listen opens a socket standing in for systemd and points
+	// NOTIFY_SOCKET at it.
+	listen := func(t *testing.T) *net.UnixConn {
+		addr := filepath.Join(t.TempDir(), "notify.sock")
+		conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: addr, Net: "unixgram"})
+		if err != nil {
+			t.Skipf("unixgram sockets are not supported: %v", err)
+		}
+		t.Cleanup(func() { conn.Close() })
+		t.Setenv("NOTIFY_SOCKET", addr)
+		t.Setenv("WATCHDOG_USEC", "")
+		return conn
+	}
+
+	// read returns the next notification received on conn, skipping
+	// watchdog pings unless asked for.
+	read := func(t *testing.T, conn *net.UnixConn, watchdog bool) string {
+		buf := make([]byte, 1024)
+		for {
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			n, err := conn.Read(buf)
+			require.NoError(t, err)
+			if msg := string(buf[:n]); watchdog || msg != "WATCHDOG=1" {
+				return msg
+			}
+		}
+	}
+
+	t.Run("lifecycle", func(t *testing.T) {
+		conn := listen(t)
+
+		var shutdowner Shutdowner
+		app := New(
+			NopLogger,
+			SystemdNotify(),
+			Populate(&shutdowner),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(ReloadHook(func() {}))
+			}),
+		)
+		require.NoError(t, app.Err())
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			app.Run()
+		}()
+		assert.Equal(t, "READY=1", read(t, conn, false))
+
+		require.NoError(t, app.Reload(context.Background()))
+		assert.Equal(t, "RELOADING=1", read(t, conn, false))
+		assert.Equal(t, "READY=1", read(t, conn, false))
+
+		require.NoError(t, shutdowner.Shutdown())
+		assert.Equal(t, "STOPPING=1", read(t, conn, false))
+		<-done
+	})
+
+	t.Run("manual lifecycle", func(t *testing.T) {
+		conn := listen(t)
+
+		app := New(NopLogger, SystemdNotify())
+		require.NoError(t, app.Err())
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		assert.Equal(t, "READY=1", read(t, conn, false))
+
+		require.NoError(t, app.Restart(ctx))
+		assert.Equal(t, "READY=1", read(t, conn, false))
+
+		require.NoError(t, app.Stop(ctx))
+		assert.Equal(t, "STOPPING=1", read(t, conn, false))
+
+		// Stopping a stopped application notifies nothing.
+		require.NoError(t, app.Stop(ctx))
+		require.NoError(t, conn.SetReadDeadline(time.Now().Add(10*time.Millisecond)))
+		_, err := conn.Read(make([]byte, 1024))
+		assert.Error(t, err, "unexpected notification")
+	})
+
+	t.Run("watchdog", func(t *testing.T) {
+		conn := listen(t)
+		t.Setenv("WATCHDOG_USEC", "10000")
+
+		app := New(NopLogger, SystemdNotify())
+		require.NoError(t, app.Err())
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		assert.Equal(t, "READY=1", read(t, conn, true))
+		assert.Equal(t, "WATCHDOG=1", read(t, conn, true))
+		assert.Equal(t, "WATCHDOG=1", read(t, conn, true))
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	t.Run("not under systemd", func(t *testing.T) {
+		t.Setenv("NOTIFY_SOCKET", "")
+
+		app := New(NopLogger, SystemdNotify())
+		require.NoError(t, app.Err())
+
+		ctx := context.Background()
+		require.NoError(t, app.Start(ctx))
+		require.NoError(t, app.Reload(ctx))
+		require.NoError(t, app.Stop(ctx))
+	})
+
+	t.Run("invalid watchdog", func(t *testing.T) {
+		listen(t)
+		t.Setenv("WATCHDOG_USEC", "soon")
+
+		err := New(NopLogger, SystemdNotify()).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `fx.SystemdNotify: invalid WATCHDOG_USEC "soon"`)
+	})
+
+	t.Run("in module", func(t *testing.T) {
+		err := New(NopLogger, Module("foo", SystemdNotify())).Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.SystemdNotify Option should be passed to top-level App")
+	})
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/fx/internal/fxclock"
+	"go.uber.org/fx/internal/lifecycle"
+)
+
+// TraceStartup records a timeline of how the application is built, started,
+// and stopped, and writes it to w in the Chrome Trace Event format. Load the
+// trace into Perfetto (https://ui.perfetto.dev) or chrome://tracing to find
+// what makes New, Start, or Stop slow.
+//
+// The timeline has a span for each constructor provided, decorator applied,
+// and function invoked by New, nested inside the spans of the modules they
+// belong to. Constructors and decorators that run appear inside the invoke
+// or constructor that needed them, separately from the spans of their
+// registration. Lifecycle hooks appear inside the spans of the Start and
+// Stop calls that ran them; hooks that run at the same time, as with
+// ConcurrentLifecycle, are placed on separate tracks.
+//
+// Events are written each time Start, Stop, or Restart completes. So that
+// later events can be appended, the trace uses the JSON Array Format and
+// omits the closing bracket of the array, which both tools accept. Errors
+// writing to w are ignored.
+func TraceStartup(w io.Writer) Option {
+	return traceStartupOption{w: w}
+}
+
+type traceStartupOption struct{ w io.Writer }
+
+func (o traceStartupOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.TraceStartup Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.traceWriter = o.w
+	}
+}
+
+func (o traceStartupOption) String() string {
+	return fmt.Sprintf("fx.TraceStartup(%T)", o.w)
+}
+
+// traceEvent is a complete event in the Chrome Trace Event format. Times are
+// in microseconds.
+type traceEvent struct {
+	Name string            `json:"name"`
+	Cat  string            `json:"cat"`
+	Ph   string            `json:"ph"`
+	Ts   float64           `json:"ts"`
+	Dur  float64           `json:"dur"`
+	Pid  int               `json:"pid"`
+	Tid  int               `json:"tid"`
+	Args map[string]string `json:"args,omitempty"`
+}
+
+// tracer records trace events and writes them to an io.Writer. A nil tracer
+// records nothing.
+type tracer struct {
+	w     io.Writer
+	clock fxclock.Clock
+	begin time.Time // time zero of the trace
+
+	mu      sync.Mutex
+	pending []traceEvent // recorded but not yet written
+	started bool         // whether the opening bracket was written
+}
+
+func newTracer(w io.Writer, clock fxclock.Clock) *tracer {
+	return &tracer{w: w, clock: clock, begin: clock.Now()}
+}
+
+// start begins a span on the main track. The span ends when the returned
+// function is called, with the error that the traced operation failed with,
+// if any.
+func (t *tracer) start(cat, name, moduleName string) (end func(error)) {
+	if t == nil {
+		return func(error) {}
+	}
+
+	begin := t.clock.Now()
+	return func(err error) {
+		t.add(traceEvent{
+			Name: name,
+			Cat:  cat,
+			Args: traceArgs(moduleName, err),
+		}, begin, t.clock.Since(begin), 0)
+	}
+}
+
+// hooks records a span for each of the given lifecycle hooks. Hooks are
+// placed on the main track unless they overlap with another hook, in which
+// case they go on the first track that's free when they start.
+func (t *tracer) hooks(records lifecycle.HookRecords) {
+	if t == nil {
+		return
+	}
+
+	records = append(lifecycle.HookRecords(nil), records...)
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Start.Before(records[j].Start)
+	})
+
+	var tracks []time.Time // end of the last hook on each track
+	for _, r := range records {
+		tid := len(tracks)
+		for i, end := range tracks {
+			if !end.After(r.Start) {
+				tid = i
+				break
+			}
+		}
+		if tid == len(tracks) {
+			tracks = append(tracks, time.Time{})
+		}
+		tracks[tid] = r.Start.Add(r.Runtime)
+
+		args := traceArgs(r.Module, r.Err)
+		args["caller"] = r.CallerFrame.Function
+		t.add(traceEvent{
+			Name: r.FuncName,
+			Cat:  r.Method,
+			Args: args,
+		}, r.Start, r.Runtime, tid)
+	}
+}
+
+func (t *tracer) add(e traceEvent, begin time.Time, runtime time.Duration, tid int) {
+	e.Ph = "X"
+	e.Ts = float64(begin.Sub(t.begin)) / float64(time.Microsecond)
+	e.Dur = float64(runtime) / float64(time.Microsecond)
+	e.Pid = 1
+	e.Tid = tid
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = append(t.pending, e)
+}
+
+// flush writes the events recorded since the last flush.
+func (t *tracer) flush() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, e := range t.pending {
+		b, err := json.Marshal(e)
+		if err != nil {
+			continue
+		}
+
+		sep := ",\n"
+		if !t.started {
+			sep = "[\n"
+			t.started = true
+		}
+		_, _ = io.WriteString(t.w, sep)
+		_, _ = t.w.Write(b)
+	}
+	t.pending = nil
+}
+
+// traceLifecycle ends a span for a call to Start, Stop, or Restart, records
+// the hooks it ran, and writes the trace.
+func (app *App) traceLifecycle(
+	end func(error),
+	err error,
+	records ...func() (lifecycle.HookRecords, time.Duration),
+) {
+	if app.tracer == nil {
+		return
+	}
+
+	end(err)
+	for _, rs := range records {
+		app.traceHooks(rs)
+	}
+	app.tracer.flush()
+}
+
+// traceHooks records the hooks run by the most recent call to Start or Stop
+// of the application's Lifecycle, as reported by records.
+func (app *App) traceHooks(records func() (lifecycle.HookRecords, time.Duration)) {
+	if app.tracer == nil {
+		return
+	}
+
+	hooks, _ := records()
+	app.tracer.hooks(hooks)
+}
+
+func traceArgs(moduleName string, err error) map[string]string {
+	args := make(map[string]string)
+	if moduleName != "" {
+		args["module"] = moduleName
+	}
+	if err != nil {
+		args["error"] = err.Error()
+	}
+	return args
+}
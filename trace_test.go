@@ -0,0 +1,301 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+)
+
+func TestTraceStartup(t *testing.T) {
+	t.Parallel()
+
+	type traceEvent struct {
+		Name string            `json:"name"`
+		Cat  string            `json:"cat"`
+		Ph   string            `json:"ph"`
+		Ts   float64           `json:"ts"`
+		Dur  float64           `json:"dur"`
+		Tid  int               `json:"tid"`
+		Args map[string]string `json:"args"`
+	}
+
+	// parse reads the events written to the buffer, closing the array
+	// that the tracer leaves open.
+	parse := func(t *testing.T, buf *bytes.Buffer) []traceEvent {
+		var events []traceEvent
+		require.NoError(t, json.Unmarshal(append(buf.Bytes(), ']'), &events))
+		return events
+	}
+
+	find := func(t *testing.T, events []traceEvent, cat, name string) traceEvent {
+		for _, e := range events {
+			if e.Cat == cat && e.Name == name {
+				return e
+			}
+		}
+		t.Fatalf("no %q event named %q in %+v", cat, name, events)
+		return traceEvent{}
+	}
+
+	type A struct{}
+	type B struct{}
+
+	t.Run("spans", func(t *testing.T) {
+		t.Parallel()
+
+		mockClock := clock.NewMock()
+		var buf bytes.Buffer
+		app := NewForTest(t,
+			TraceStartup(&buf),
+			WithClock(mockClock),
+			Module("child",
+				Provide(func(lc Lifecycle) *A {
+					mockClock.Add(time.Second)
+					lc.Append(Hook{
+						OnStart: func(context.Context) error {
+							mockClock.Add(2 * time.Second)
+							return nil
+						},
+						OnStop: func(context.Context) error {
+							mockClock.Add(3 * time.Second)
+							return nil
+						},
+					})
+					return &A{}
+				}),
+				Decorate(func(a *A) *A {
+					mockClock.Add(4 * time.Second)
+					return a
+				}),
+				Invoke(func(*A, *B) {}),
+			),
+			Supply(&B{}),
+		)
+		require.NoError(t, app.Err())
+		assert.Zero(t, buf.Len(), "nothing should be written before Start")
+
+		require.NoError(t, app.Start(context.Background()))
+		events := parse(t, &buf)
+		for _, e := range events {
+			assert.Equal(t, "X", e.Ph, "unexpected phase for %q", e.Name)
+		}
+
+		newSpan := find(t, events, "fx", "New")
+		assert.Equal(t, float64(0), newSpan.Ts)
+		assert.Equal(t, float64(5*time.Second/time.Microsecond), newSpan.Dur)
+
+		module := find(t, events, "module", "child")
+		assert.Empty(t, module.Args["module"])
+
+		supply := find(t, events, "supply", "stub(*fx_test.B)")
+		assert.Empty(t, supply.Args["module"])
+
+		newA := "go.uber.org/fx_test.TestTraceStartup.func3.1()"
+		provide := find(t, events, "provide", newA)
+		assert.Equal(t, "child", provide.Args["module"])
+		decorateA := "go.uber.org/fx_test.TestTraceStartup.func3.2()"
+		decorate := find(t, events, "decorate", decorateA)
+		assert.Equal(t, "child", decorate.Args["module"])
+		assert.Zero(t, decorate.Dur, "decorators should not run when applied")
+		run := find(t, events, "run,provide", newA)
+		assert.Equal(t, "child", run.Args["module"])
+		assert.Equal(t, float64(time.Second/time.Microsecond), run.Dur)
+		runDecorate := find(t, events, "run,decorate", decorateA)
+		assert.Equal(t, "child", runDecorate.Args["module"])
+		assert.Equal(t, float64(4*time.Second/time.Microsecond), runDecorate.Dur)
+		assert.GreaterOrEqual(t, runDecorate.Ts, run.Ts+run.Dur, "decorator should run after the constructor it decorates")
+
+		invoke := find(t, events, "invoke", "go.uber.org/fx_test.TestTraceStartup.func3.3()")
+		for _, r := range []traceEvent{run, runDecorate} {
+			assert.LessOrEqual(t, invoke.Ts, r.Ts, "invoke should start before %q", r.Name)
+			assert.GreaterOrEqual(t, invoke.Ts+invoke.Dur, r.Ts+r.Dur, "invoke should end after %q", r.Name)
+		}
+
+		start := find(t, events, "fx", "Start")
+		onStart := find(t, events, "OnStart", "go.uber.org/fx_test.TestTraceStartup.func3.1.1()")
+		assert.Equal(t, "child", onStart.Args["module"])
+		assert.Equal(t, float64(2*time.Second/time.Microsecond), onStart.Dur)
+		assert.Equal(t, start.Ts, onStart.Ts)
+		assert.Equal(t, 0, onStart.Tid)
+
+		// Stop appends to the events written by Start.
+		n := len(events)
+		require.NoError(t, app.Stop(context.Background()))
+		events = parse(t, &buf)[n:]
+		require.Len(t, events, 2, "expected only the Stop span and its hook")
+		find(t, events, "fx", "Stop")
+		onStop := find(t, events, "OnStop", "go.uber.org/fx_test.TestTraceStartup.func3.1.2()")
+		assert.Equal(t, float64(3*time.Second/time.Microsecond), onStop.Dur)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		app := NewForTest(t,
+			TraceStartup(&buf),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(context.Context) error {
+						return errors.New("great sadness")
+					},
+				})
+			}),
+		)
+		require.NoError(t, app.Err())
+
+		require.Error(t, app.Start(context.Background()))
+		events := parse(t, &buf)
+
+		start := find(t, events, "fx", "Start")
+		assert.Contains(t, start.Args["error"], "great sadness")
+
+		var hooks int
+		for _, e := range events {
+			if e.Cat == "OnStart" {
+				hooks++
+				assert.Equal(t, "great sadness", e.Args["error"])
+			}
+		}
+		assert.Equal(t, 1, hooks)
+	})
+
+	t.Run("failed restart", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		var stops int
+		app := NewForTest(t,
+			TraceStartup(&buf),
+			Invoke(func(lc Lifecycle) {
+				lc.Append(Hook{
+					OnStart: func(context.Context) error { return nil },
+					OnStop: func(context.Context) error {
+						if stops++; stops == 1 {
+							return errors.New("great sadness")
+						}
+						return nil
+					},
+				})
+			}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		n := len(parse(t, &buf))
+
+		// The stop half of the restart fails, so the start half never
+		// runs and the hooks of the earlier Start must not reappear.
+		require.Error(t, app.Restart(context.Background()))
+		events := parse(t, &buf)[n:]
+
+		restart := find(t, events, "fx", "Restart")
+		assert.Contains(t, restart.Args["error"], "great sadness")
+		var cats []string
+		for _, e := range events {
+			cats = append(cats, e.Cat)
+		}
+		assert.ElementsMatch(t, []string{"fx", "OnStop"}, cats)
+
+		require.NoError(t, app.Stop(context.Background()))
+	})
+
+	t.Run("start skipped", func(t *testing.T) {
+		t.Parallel()
+
+		var buf bytes.Buffer
+		app := NewForTest(t,
+			TraceStartup(&buf),
+			Invoke(func(s Shutdowner) error { return s.Shutdown() }),
+		)
+		require.NoError(t, app.Start(context.Background()))
+
+		events := parse(t, &buf)
+		find(t, events, "fx", "New")
+		find(t, events, "fx", "Start")
+	})
+
+	t.Run("concurrent hooks", func(t *testing.T) {
+		t.Parallel()
+
+		// Each hook waits until both hooks are running so that
+		// they overlap.
+		var wg sync.WaitGroup
+		wg.Add(2)
+		hook := Hook{
+			OnStart: func(context.Context) error {
+				wg.Done()
+				wg.Wait()
+				time.Sleep(time.Millisecond)
+				return nil
+			},
+		}
+
+		var buf bytes.Buffer
+		app := NewForTest(t,
+			TraceStartup(&buf),
+			ConcurrentLifecycle(),
+			Provide(
+				func(lc Lifecycle) *A {
+					lc.Append(hook)
+					return &A{}
+				},
+				func(lc Lifecycle) *B {
+					lc.Append(hook)
+					return &B{}
+				},
+			),
+			Invoke(func(*A, *B) {}),
+		)
+		require.NoError(t, app.Start(context.Background()))
+		defer app.Stop(context.Background())
+
+		var tids []int
+		for _, e := range parse(t, &buf) {
+			if e.Cat == "OnStart" {
+				tids = append(tids, e.Tid)
+			}
+		}
+		assert.ElementsMatch(t, []int{0, 1}, tids,
+			"overlapping hooks should be on separate tracks")
+	})
+
+	t.Run("passed to module", func(t *testing.T) {
+		t.Parallel()
+
+		app := NewForTest(t,
+			Module("child", TraceStartup(&bytes.Buffer{})),
+		)
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(),
+			"fx.TraceStartup Option should be passed to top-level App, not to fx.Module")
+	})
+}
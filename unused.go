@@ -0,0 +1,187 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx
+
+import (
+	"fmt"
+	"reflect"
+
+	"go.uber.org/dig"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxreflect"
+)
+
+// ReportUnused reports the values that were provided to the application but
+// that nothing reachable from an fx.Invoke consumes. Because Fx calls
+// constructors lazily, the constructors of such values never run, and are
+// usually left over from refactors.
+//
+// After the application's invokes run, an fxevent.Unused event is emitted
+// for each constructor or supplied value with unused values, grouped by
+// module. The values Fx provides to all applications, such as Lifecycle, are
+// not reported.
+//
+// ReportUnused may be used with ValidateApp to report unused values without
+// running any constructors.
+func ReportUnused() Option {
+	return reportUnusedOption{}
+}
+
+type reportUnusedOption struct{}
+
+func (o reportUnusedOption) apply(m *module) {
+	if m.parent != nil {
+		m.app.err = fmt.Errorf("fx.ReportUnused Option should be passed to top-level " +
+			"App, not to fx.Module")
+	} else {
+		m.app.reportUnused = true
+	}
+}
+
+func (o reportUnusedOption) String() string {
+	return "fx.ReportUnused()"
+}
+
+// logUnused emits an fxevent.Unused event for each constructor that produces
+// values that nothing reachable from an invoke consumes.
+func (app *App) logUnused() {
+	// Values are needed by functions in a module. Like the container,
+	// resolve them against the constructors visible from that module.
+	type need struct {
+		module *module
+		key    string
+	}
+	seen := make(map[need]struct{})
+	var queue []need
+	needAll := func(m *module, keys []string) {
+		for _, k := range keys {
+			n := need{module: m, key: k}
+			if _, ok := seen[n]; !ok {
+				seen[n] = struct{}{}
+				queue = append(queue, n)
+			}
+		}
+	}
+
+	var visit func(m *module)
+	visit = func(m *module) {
+		for _, i := range m.invokes {
+			needAll(m, paramKeys(i.Target))
+		}
+		if m.logConstructor != nil {
+			needAll(m, paramKeys(m.logConstructor.Target))
+		}
+		for _, sub := range m.modules {
+			visit(sub)
+		}
+	}
+	visit(app.root)
+
+	used := make(map[*constructorNode]map[string]struct{})
+	for len(queue) > 0 {
+		n := queue[0]
+		queue = queue[1:]
+		app.resolve(n.module, n.key, nil, func(p *constructorNode, key string) {
+			if used[p] == nil {
+				used[p] = make(map[string]struct{})
+			}
+			used[p][key] = struct{}{}
+			needAll(p.module, p.inputs)
+		})
+	}
+
+	for _, n := range app.constructors {
+		if n.provide.IsBuiltin {
+			continue
+		}
+
+		var unused []string
+		for _, out := range n.outputs {
+			if _, ok := used[n][out]; !ok {
+				unused = append(unused, out)
+			}
+		}
+		if len(unused) == 0 {
+			continue
+		}
+
+		ev := &fxevent.Unused{
+			OutputTypeNames: unused,
+			ModuleName:      n.module.name,
+		}
+		if !n.provide.IsSupply {
+			ev.ConstructorName = fxreflect.FuncName(n.provide.Target)
+		}
+		if len(n.provide.Stack) > 0 {
+			ev.Location = n.provide.Stack[0].String()
+		}
+		n.module.log.LogEvent(ev)
+	}
+}
+
+// paramKeys returns the keys of the values consumed by the given function,
+// such as a function passed to fx.Invoke.
+//
+// dig reports the inputs of constructors but not of invoked functions, so
+// we provide a constructor with the same parameters to a scratch container
+// and inspect that instead.
+func paramKeys(fn interface{}) []string {
+	if ann, ok := fn.(annotated); ok {
+		built, err := ann.Build()
+		if err != nil {
+			return nil
+		}
+		fn = built
+	}
+
+	ft := reflect.TypeOf(fn)
+	if ft == nil || ft.Kind() != reflect.Func {
+		return nil
+	}
+
+	ins := make([]reflect.Type, ft.NumIn())
+	for i := range ins {
+		ins[i] = ft.In(i)
+	}
+	ctorType := reflect.FuncOf(ins, []reflect.Type{_paramKeysType}, ft.IsVariadic())
+	ctor := reflect.MakeFunc(ctorType, func([]reflect.Value) []reflect.Value {
+		return []reflect.Value{reflect.Zero(_paramKeysType)}
+	})
+
+	var info dig.ProvideInfo
+	if err := dig.New().Provide(ctor.Interface(), dig.FillProvideInfo(&info)); err != nil {
+		return nil
+	}
+	return inputKeys(info.Inputs)
+}
+
+// paramKeysResult is the result of the constructors built by paramKeys.
+type paramKeysResult struct{}
+
+var _paramKeysType = reflect.TypeOf(paramKeysResult{})
+
+func inputKeys(ins []*dig.Input) []string {
+	keys := make([]string, len(ins))
+	for i, in := range ins {
+		keys[i] = inputKey(in)
+	}
+	return keys
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2023 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package fx_test
+
+import (
+	"bytes"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	. "go.uber.org/fx"
+	"go.uber.org/fx/fxevent"
+	"go.uber.org/fx/internal/fxlog"
+)
+
+func TestReportUnused(t *testing.T) {
+	t.Parallel()
+
+	type A struct{}
+	type B struct{}
+	type C struct{}
+	type D struct{}
+
+	unusedEvents := func(spy *fxlog.Spy) []*fxevent.Unused {
+		var events []*fxevent.Unused
+		for _, e := range spy.Events().SelectByTypeName("Unused") {
+			events = append(events, e.(*fxevent.Unused))
+		}
+		return events
+	}
+
+	t.Run("reports unused values", func(t *testing.T) {
+		t.Parallel()
+
+		newA := func(*B) *A { return &A{} }
+		newC := func() *C { return &C{} }
+		app, spy := NewSpied(
+			ReportUnused(),
+			Provide(newA, func() *B { return &B{} }),
+			Module("child",
+				Provide(newC),
+				Supply(&D{}),
+			),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+
+		events := unusedEvents(spy)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, []string{"*fx_test.C"}, events[0].OutputTypeNames)
+		assert.Contains(t, events[0].ConstructorName, "TestReportUnused")
+		assert.Equal(t, "child", events[0].ModuleName)
+		assert.Contains(t, events[0].Location, "TestReportUnused")
+		assert.Contains(t, events[0].Location, "unused_test.go")
+
+		assert.Equal(t, []string{"*fx_test.D"}, events[1].OutputTypeNames)
+		assert.Empty(t, events[1].ConstructorName)
+		assert.Equal(t, "child", events[1].ModuleName)
+	})
+
+	t.Run("partially unused constructor", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(
+			ReportUnused(),
+			Provide(func() (*A, *B) { return &A{}, &B{} }),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+
+		events := unusedEvents(spy)
+		require.Len(t, events, 1)
+		assert.Equal(t, []string{"*fx_test.B"}, events[0].OutputTypeNames)
+	})
+
+	t.Run("parameter objects, names, and groups", func(t *testing.T) {
+		t.Parallel()
+
+		type params struct {
+			In
+
+			A  *A   `name:"a"`
+			Bs []*B `group:"bs"`
+			C  *C   `optional:"true"`
+		}
+		app, spy := NewSpied(
+			ReportUnused(),
+			Provide(
+				Annotate(func() *A { return &A{} }, ResultTags(`name:"a"`)),
+				Annotate(func() *A { return &A{} }, ResultTags(`name:"unused"`)),
+				Annotate(func() *B { return &B{} }, ResultTags(`group:"bs"`)),
+				Annotate(func() *B { return &B{} }, ResultTags(`group:"bs"`)),
+				func() *C { return &C{} },
+			),
+			Invoke(func(params) {}),
+		)
+		require.NoError(t, app.Err())
+
+		events := unusedEvents(spy)
+		require.Len(t, events, 1)
+		assert.Equal(t, []string{`*fx_test.A[name = "unused"]`}, events[0].OutputTypeNames)
+	})
+
+	t.Run("decorator and logger inputs", func(t *testing.T) {
+		t.Parallel()
+
+		spy := new(fxlog.Spy)
+		app := New(
+			ReportUnused(),
+			Supply(spy),
+			WithLogger(func(spy *fxlog.Spy) fxevent.Logger { return spy }),
+			Provide(
+				func() *A { return &A{} },
+				func() *B { return &B{} },
+				func() *C { return &C{} },
+			),
+			Decorate(func(a *A, _ *B) *A { return a }),
+			Decorate(func(d *D, _ *C) *D { return d }),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, app.Err())
+
+		events := unusedEvents(spy)
+		require.Len(t, events, 1)
+		assert.Equal(t, []string{"*fx_test.C"}, events[0].OutputTypeNames,
+			"inputs of decorators of unused types must be unused")
+	})
+
+	t.Run("scopes", func(t *testing.T) {
+		t.Parallel()
+
+		// Only module "a" consumes its private *A, and the *B provided
+		// privately in module "b" shadows the top-level one.
+		app, spy := NewSpied(
+			ReportUnused(),
+			Provide(func() *B { return &B{} }),
+			Module("a",
+				Provide(Private, func() *A { return &A{} }),
+				Invoke(func(*A) {}),
+			),
+			Module("b",
+				Provide(Private, func() *A { return &A{} }),
+				Provide(Private, func() *B { return &B{} }),
+				Invoke(func(*B) {}),
+			),
+		)
+		require.NoError(t, app.Err())
+
+		events := unusedEvents(spy)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, []string{"*fx_test.B"}, events[0].OutputTypeNames)
+		assert.Empty(t, events[0].ModuleName)
+
+		assert.Equal(t, []string{"*fx_test.A"}, events[1].OutputTypeNames)
+		assert.Equal(t, "b", events[1].ModuleName)
+	})
+
+	t.Run("validation", func(t *testing.T) {
+		t.Parallel()
+
+		// Custom loggers aren't constructed when validating.
+		var out bytes.Buffer
+		err := ValidateApp(
+			ReportUnused(),
+			Logger(log.New(&out, "", 0)),
+			Provide(
+				func() *A { panic("must not run") },
+				func() *B { panic("must not run") },
+			),
+			Invoke(func(*A) {}),
+		)
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "[Fx] UNUSED\t*fx_test.B <= ")
+		assert.NotContains(t, out.String(), "UNUSED\t*fx_test.A")
+	})
+
+	t.Run("not reported by default", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(Provide(func() *A { return &A{} }))
+		require.NoError(t, app.Err())
+		assert.Empty(t, unusedEvents(spy))
+	})
+
+	t.Run("not reported if invokes fail", func(t *testing.T) {
+		t.Parallel()
+
+		app, spy := NewSpied(
+			ReportUnused(),
+			Provide(func() *A { return &A{} }),
+			Invoke(func(*B) {}),
+		)
+		require.Error(t, app.Err())
+		assert.Empty(t, unusedEvents(spy))
+	})
+
+	t.Run("in module", func(t *testing.T) {
+		t.Parallel()
+
+		app := New(NopLogger, Module("child", ReportUnused()))
+		err := app.Err()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fx.ReportUnused Option should be passed to top-level App")
+	})
+}